@@ -0,0 +1,315 @@
+package adcptest
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type command struct {
+	get func(s *Server) string
+	set func(s *Server, arg string) string
+
+	// needsPower is true if the command answers err_inactive unless the projector is on
+	needsPower bool
+}
+
+// Inputs are the inputs the simulated projector accepts.
+var Inputs = []string{"hdmi1", "hdmi2", "dvi1", "video1", "rgb1", "rgb2", "hdbaset1"}
+
+// Values the simulated projector accepts for picture_mode, color_temp, and gamma_correction.
+var (
+	PictureModes = []string{"dynamic", "standard", "presentation", "cinema", "game", "user"}
+	ColorTemps   = []string{"high", "middle", "low", "custom"}
+	Gammas       = []string{"off", "1.8", "2.0", "2.2", "2.4", "2.6"}
+)
+
+// The simulated lens moves between 0 and MaxLensPosition on each axis, and
+// stores positions in lens memories 1 through LensMemories.
+const (
+	MaxLensPosition = 100
+	LensMemories    = 6
+)
+
+var commands = map[string]command{
+	"power_status": {get: func(s *Server) string { return quote(s.state.Power) }},
+	"power":        {set: setPower},
+	"input": {
+		get:        func(s *Server) string { return quote(s.state.Input) },
+		set:        setInput,
+		needsPower: true,
+	},
+	"signal": {get: getSignal},
+	"volume": {
+		get:        func(s *Server) string { return strconv.Itoa(s.state.Volume) },
+		set:        func(s *Server, arg string) string { return setNumber(&s.state.Volume, 0, 100, arg) },
+		needsPower: true,
+	},
+	"muting": {
+		get:        func(s *Server) string { return onOff(s.state.Muted) },
+		set:        func(s *Server, arg string) string { return setOnOff(&s.state.Muted, arg) },
+		needsPower: true,
+	},
+	"blank": {
+		get:        func(s *Server) string { return onOff(s.state.Blanked) },
+		set:        func(s *Server, arg string) string { return setOnOff(&s.state.Blanked, arg) },
+		needsPower: true,
+	},
+	"picture_mode": {
+		get:        func(s *Server) string { return quote(s.state.PictureMode) },
+		set:        func(s *Server, arg string) string { return setChoice(&s.state.PictureMode, PictureModes, arg) },
+		needsPower: true,
+	},
+	"brightness": {
+		get:        func(s *Server) string { return strconv.Itoa(s.state.Brightness) },
+		set:        func(s *Server, arg string) string { return setNumber(&s.state.Brightness, 0, 100, arg) },
+		needsPower: true,
+	},
+	"contrast": {
+		get:        func(s *Server) string { return strconv.Itoa(s.state.Contrast) },
+		set:        func(s *Server, arg string) string { return setNumber(&s.state.Contrast, 0, 100, arg) },
+		needsPower: true,
+	},
+	"color": {
+		get:        func(s *Server) string { return strconv.Itoa(s.state.Color) },
+		set:        func(s *Server, arg string) string { return setNumber(&s.state.Color, 0, 100, arg) },
+		needsPower: true,
+	},
+	"hue": {
+		get:        func(s *Server) string { return strconv.Itoa(s.state.Hue) },
+		set:        func(s *Server, arg string) string { return setNumber(&s.state.Hue, 0, 100, arg) },
+		needsPower: true,
+	},
+	"sharpness": {
+		get:        func(s *Server) string { return strconv.Itoa(s.state.Sharpness) },
+		set:        func(s *Server, arg string) string { return setNumber(&s.state.Sharpness, 0, 100, arg) },
+		needsPower: true,
+	},
+	"color_temp": {
+		get:        func(s *Server) string { return quote(s.state.ColorTemp) },
+		set:        func(s *Server, arg string) string { return setChoice(&s.state.ColorTemp, ColorTemps, arg) },
+		needsPower: true,
+	},
+	"gamma_correction": {
+		get:        func(s *Server) string { return quote(s.state.Gamma) },
+		set:        func(s *Server, arg string) string { return setChoice(&s.state.Gamma, Gammas, arg) },
+		needsPower: true,
+	},
+	"lens_zoom": {
+		get:        func(s *Server) string { return strconv.Itoa(s.state.Lens.Zoom) },
+		set:        func(s *Server, arg string) string { return stepLens(&s.state.Lens.Zoom, arg) },
+		needsPower: true,
+	},
+	"lens_focus": {
+		get:        func(s *Server) string { return strconv.Itoa(s.state.Lens.Focus) },
+		set:        func(s *Server, arg string) string { return stepLens(&s.state.Lens.Focus, arg) },
+		needsPower: true,
+	},
+	"lens_shift_h": {
+		get:        func(s *Server) string { return strconv.Itoa(s.state.Lens.ShiftH) },
+		set:        func(s *Server, arg string) string { return stepLens(&s.state.Lens.ShiftH, arg) },
+		needsPower: true,
+	},
+	"lens_shift_v": {
+		get:        func(s *Server) string { return strconv.Itoa(s.state.Lens.ShiftV) },
+		set:        func(s *Server, arg string) string { return stepLens(&s.state.Lens.ShiftV, arg) },
+		needsPower: true,
+	},
+	"lens_memory":          {set: setLensMemory, needsPower: true},
+	"warning":              {get: func(s *Server) string { return marshal(s.state.Warnings) }},
+	"error":                {get: func(s *Server) string { return marshal(s.state.Errors) }},
+	"timer":                {get: func(s *Server) string { return marshal(s.state.Timers) }},
+	"modelname":            {get: func(s *Server) string { return quote(s.state.ModelName) }},
+	"serialnum":            {get: func(s *Server) string { return quote(s.state.SerialNumber) }},
+	"ipv4_ip_address":      {get: func(s *Server) string { return quote(s.state.IPAddress) }},
+	"ipv4_default_gateway": {get: func(s *Server) string { return quote(s.state.Gateway) }},
+	"ipv4_dns_server1":     {get: func(s *Server) string { return quote(s.state.DNS1) }},
+	"ipv4_dns_server2":     {get: func(s *Server) string { return quote(s.state.DNS2) }},
+	"mac_address":          {get: func(s *Server) string { return quote(s.state.MACAddress) }},
+	"filter_status":        {get: func(s *Server) string { return quote(s.state.FilterStatus) }},
+}
+
+func setPower(s *Server, arg string) string {
+	str, ok := unquote(arg)
+	if !ok {
+		return "err_cmd"
+	}
+
+	switch str {
+	case "on":
+		switch s.state.Power {
+		case PowerOn, PowerStartup:
+		case PowerStandby, PowerSavingStandby:
+			s.state.Power = PowerStartup
+			s.state.transitionStart = time.Now()
+		default:
+			return "err_inactive"
+		}
+	case "off":
+		switch s.state.Power {
+		case PowerOn, PowerStartup:
+			s.state.Power = PowerCooling1
+			s.state.transitionStart = time.Now()
+		}
+	default:
+		return "err_val"
+	}
+
+	s.advance()
+	return "ok"
+}
+
+func setInput(s *Server, arg string) string {
+	str, ok := unquote(arg)
+	if !ok {
+		return "err_cmd"
+	}
+
+	for _, input := range Inputs {
+		if input == str {
+			s.state.Input = str
+			return "ok"
+		}
+	}
+
+	return "err_val"
+}
+
+// setChoice sets val to the quoted string in arg, if it is one of choices.
+func setChoice(val *string, choices []string, arg string) string {
+	str, ok := unquote(arg)
+	if !ok {
+		return "err_cmd"
+	}
+
+	for _, choice := range choices {
+		if choice == str {
+			*val = str
+			return "ok"
+		}
+	}
+
+	return "err_val"
+}
+
+// stepLens moves one axis of the lens by a step. Unlike other numbers, lens
+// positions can only be stepped, not set directly.
+func stepLens(val *int, arg string) string {
+	if arg != "++" && arg != "--" {
+		return "err_cmd"
+	}
+
+	return setNumber(val, 0, MaxLensPosition, arg)
+}
+
+// setLensMemory handles `lens_memory "load" 1` and `lens_memory "save" 1`.
+func setLensMemory(s *Server, arg string) string {
+	fields := strings.Fields(arg)
+	if len(fields) != 2 {
+		return "err_cmd"
+	}
+
+	action, ok := unquote(fields[0])
+	if !ok {
+		return "err_cmd"
+	}
+
+	n, err := strconv.Atoi(fields[1])
+	switch {
+	case err != nil:
+		return "err_cmd"
+	case n < 1 || n > LensMemories:
+		return "err_val"
+	}
+
+	switch action {
+	case "load":
+		pos, ok := s.state.LensMemories[n]
+		if !ok {
+			return "err_val"
+		}
+
+		s.state.Lens = pos
+	case "save":
+		s.state.LensMemories[n] = s.state.Lens
+	default:
+		return "err_val"
+	}
+
+	return "ok"
+}
+
+func getSignal(s *Server) string {
+	if s.state.Power != PowerOn {
+		return quote("Invalid")
+	}
+
+	signal, ok := s.state.Signals[s.state.Input]
+	if !ok || signal == "" {
+		return quote("Invalid")
+	}
+
+	return quote(signal)
+}
+
+// setNumber sets val to the number in arg, or moves it by one if arg is "++" or "--".
+func setNumber(val *int, min, max int, arg string) string {
+	next := *val
+
+	switch arg {
+	case "++":
+		next++
+	case "--":
+		next--
+	default:
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return "err_cmd"
+		}
+
+		next = n
+	}
+
+	if next < min || next > max {
+		return "err_val"
+	}
+
+	*val = next
+	return "ok"
+}
+
+func onOff(b bool) string {
+	if b {
+		return quote("on")
+	}
+
+	return quote("off")
+}
+
+func setOnOff(val *bool, arg string) string {
+	str, ok := unquote(arg)
+	if !ok {
+		return "err_cmd"
+	}
+
+	switch str {
+	case "on":
+		*val = true
+	case "off":
+		*val = false
+	default:
+		return "err_val"
+	}
+
+	return "ok"
+}
+
+func marshal(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "err_internal1"
+	}
+
+	return string(b)
+}
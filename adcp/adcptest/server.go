@@ -0,0 +1,306 @@
+/*
+Package adcptest provides a simulated Sony projector that speaks ADCP over TCP, for use in tests
+that would otherwise need a real projector.
+
+The simulator answers the same commands the adcp package sends, tracks power, input, volume,
+mute and blank state, and walks through the startup and cooling power states the way a real
+projector does.
+*/
+package adcptest
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server is a simulated projector.
+type Server struct {
+	// Listener is the listener the server accepts connections on.
+	Listener net.Listener
+
+	mu       sync.Mutex
+	password string
+	state    State
+	errors   map[string]string
+	startup  time.Duration
+	cooling  time.Duration
+	conns    map[net.Conn]struct{}
+	wg       sync.WaitGroup
+	closed   bool
+}
+
+// NewServer starts and returns a new simulated projector listening on a local port.
+// The caller should call Close when finished.
+func NewServer() *Server {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic("adcptest: failed to listen on a port: " + err.Error())
+	}
+
+	s := &Server{
+		Listener: l,
+		state:    DefaultState(),
+		errors:   make(map[string]string),
+		conns:    make(map[net.Conn]struct{}),
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	return s
+}
+
+// Host returns the host the server is listening on.
+func (s *Server) Host() string {
+	host, _, _ := net.SplitHostPort(s.Listener.Addr().String())
+	return host
+}
+
+// Port returns the port the server is listening on.
+func (s *Server) Port() int {
+	return s.Listener.Addr().(*net.TCPAddr).Port
+}
+
+// Close stops the server and closes any open connections.
+func (s *Server) Close() {
+	s.mu.Lock()
+	s.closed = true
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.Listener.Close()
+	s.wg.Wait()
+}
+
+// State returns a copy of the current state of the projector.
+func (s *Server) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.advance()
+	return s.state.copy()
+}
+
+// SetState calls f with the current state of the projector, allowing it to be modified.
+func (s *Server) SetState(f func(*State)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.advance()
+	f(&s.state)
+}
+
+// SetTransitionTimes sets how long the projector spends in the startup state after
+// being powered on, and in the cooling states after being powered off. Both default to 0.
+func (s *Server) SetTransitionTimes(startup, cooling time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.startup = startup
+	s.cooling = cooling
+}
+
+// SetPassword enables network authentication on the projector. New connections are sent
+// a random key and must answer with the hex encoded SHA-256 hash of the key followed by
+// password before any commands are accepted. An empty password disables authentication.
+func (s *Server) SetPassword(password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.password = password
+}
+
+// SetError causes every following command named cmd (ie, "input") to be answered
+// with resp (ie, "err_internal1") until ClearError is called.
+func (s *Server) SetError(cmd, resp string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errors[cmd] = resp
+}
+
+// ClearError removes an error set by SetError.
+func (s *Server) ClearError(cmd string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.errors, cmd)
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.Listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer func() {
+				s.mu.Lock()
+				delete(s.conns, conn)
+				s.mu.Unlock()
+
+				conn.Close()
+			}()
+
+			s.handle(conn)
+		}()
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	r := bufio.NewReader(conn)
+
+	s.mu.Lock()
+	password := s.password
+	s.mu.Unlock()
+
+	if password == "" {
+		writeLine(conn, "NOKEY")
+	} else {
+		key := randomKey()
+		writeLine(conn, key)
+
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		hash := sha256.Sum256([]byte(key + password))
+		if strings.TrimSpace(line) != hex.EncodeToString(hash[:]) {
+			writeLine(conn, "err_auth")
+			return
+		}
+
+		writeLine(conn, "OK")
+	}
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		s.mu.Lock()
+		resp := s.command(line)
+		s.mu.Unlock()
+
+		writeLine(conn, resp)
+	}
+}
+
+func writeLine(conn net.Conn, line string) {
+	conn.Write([]byte(line + "\r\n"))
+}
+
+// command must be called with s.mu held.
+func (s *Server) command(line string) string {
+	s.advance()
+
+	name, arg := line, ""
+	if i := strings.IndexByte(line, ' '); i >= 0 {
+		name, arg = line[:i], strings.TrimSpace(line[i+1:])
+	}
+
+	if resp, ok := s.errors[name]; ok {
+		return resp
+	}
+
+	c, ok := commands[name]
+	if !ok {
+		return "err_cmd"
+	}
+
+	if arg == "?" {
+		if c.get == nil {
+			return "err_cmd"
+		}
+
+		return c.get(s)
+	}
+
+	if c.set == nil {
+		return "err_cmd"
+	}
+
+	if c.needsPower && s.state.Power != PowerOn {
+		return "err_inactive"
+	}
+
+	return c.set(s, arg)
+}
+
+// advance moves the projector through its startup and cooling states. It must
+// be called with s.mu held.
+func (s *Server) advance() {
+	if s.state.transitionStart.IsZero() {
+		return
+	}
+
+	elapsed := time.Since(s.state.transitionStart)
+
+	switch s.state.Power {
+	case PowerStartup:
+		if elapsed >= s.startup {
+			s.state.Power = PowerOn
+			s.state.transitionStart = time.Time{}
+		}
+	case PowerCooling1, PowerCooling2:
+		switch {
+		case elapsed >= s.cooling:
+			s.state.Power = PowerStandby
+			s.state.transitionStart = time.Time{}
+		case elapsed >= s.cooling/2:
+			s.state.Power = PowerCooling2
+		}
+	default:
+		s.state.transitionStart = time.Time{}
+	}
+}
+
+func randomKey() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+func quote(str string) string {
+	return strconv.Quote(str)
+}
+
+func unquote(str string) (string, bool) {
+	if len(str) < 2 || str[0] != '"' || str[len(str)-1] != '"' {
+		return "", false
+	}
+
+	return str[1 : len(str)-1], true
+}
@@ -0,0 +1,131 @@
+package adcptest
+
+import "time"
+
+// Power states reported by power_status.
+const (
+	PowerStandby        = "standby"
+	PowerStartup        = "startup"
+	PowerOn             = "on"
+	PowerCooling1       = "cooling1"
+	PowerCooling2       = "cooling2"
+	PowerSavingCooling1 = "saving_cooling1"
+	PowerSavingCooling2 = "saving_cooling2"
+	PowerSavingStandby  = "saving_standby"
+)
+
+// State is the simulated state of a projector.
+type State struct {
+	Power string
+
+	Input string
+
+	// Signals maps an input to the signal on it, ie "1920x1080/60p".
+	// Inputs without a signal report "Invalid".
+	Signals map[string]string
+
+	Volume  int
+	Muted   bool
+	Blanked bool
+
+	PictureMode string
+	Brightness  int
+	Contrast    int
+	Color       int
+	Hue         int
+	Sharpness   int
+	ColorTemp   string
+	Gamma       string
+
+	Lens LensPosition
+
+	// LensMemories maps a lens memory to the position saved in it
+	LensMemories map[int]LensPosition
+
+	Warnings []string
+	Errors   []string
+	Timers   []map[string]int
+
+	ModelName    string
+	SerialNumber string
+	IPAddress    string
+	Gateway      string
+	DNS1         string
+	DNS2         string
+	MACAddress   string
+	FilterStatus string
+
+	transitionStart time.Time
+}
+
+// LensPosition is the position of the lens on each axis, from 0 to MaxLensPosition.
+type LensPosition struct {
+	Zoom   int
+	Focus  int
+	ShiftH int
+	ShiftV int
+}
+
+// DefaultState returns the state a new Server starts with: a projector in
+// standby on hdmi1, with a signal only on hdmi1.
+func DefaultState() State {
+	return State{
+		Power: PowerStandby,
+		Input: "hdmi1",
+		Signals: map[string]string{
+			"hdmi1": "1920x1080/60p",
+		},
+		Volume: 25,
+
+		PictureMode: "standard",
+		Brightness:  50,
+		Contrast:    80,
+		Color:       50,
+		Hue:         50,
+		Sharpness:   10,
+		ColorTemp:   "middle",
+		Gamma:       "2.2",
+
+		Lens: LensPosition{
+			Zoom:   MaxLensPosition / 2,
+			Focus:  MaxLensPosition / 2,
+			ShiftH: MaxLensPosition / 2,
+			ShiftV: MaxLensPosition / 2,
+		},
+		LensMemories: map[int]LensPosition{},
+
+		Warnings: []string{},
+		Errors:   []string{},
+		Timers: []map[string]int{
+			{"operation": 1200},
+			{"light_src": 1100},
+		},
+		ModelName:    "VPL-PHZ10",
+		SerialNumber: "1234567",
+		IPAddress:    "192.0.2.10",
+		Gateway:      "192.0.2.1",
+		DNS1:         "192.0.2.2",
+		DNS2:         "192.0.2.3",
+		MACAddress:   "00:00:5e:00:53:02",
+		FilterStatus: "ok",
+	}
+}
+
+func (s State) copy() State {
+	signals := make(map[string]string, len(s.Signals))
+	for k, v := range s.Signals {
+		signals[k] = v
+	}
+
+	memories := make(map[int]LensPosition, len(s.LensMemories))
+	for k, v := range s.LensMemories {
+		memories[k] = v
+	}
+
+	s.Signals = signals
+	s.LensMemories = memories
+	s.Warnings = append([]string(nil), s.Warnings...)
+	s.Errors = append([]string(nil), s.Errors...)
+	s.Timers = append([]map[string]int(nil), s.Timers...)
+	return s
+}
@@ -2,8 +2,10 @@ package adcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"time"
 )
 
 var (
@@ -15,7 +17,7 @@ var (
 func (p *Projector) Volumes(ctx context.Context, blocks []string) (map[string]int, error) {
 	toReturn := make(map[string]int)
 
-	resp, err := p.SendCommand(ctx, p.Address, volumeStatus)
+	resp, err := p.send(ctx, volumeStatus)
 	if err != nil {
 		return toReturn, err
 	}
@@ -35,13 +37,103 @@ func (p *Projector) SetVolume(ctx context.Context, block string, level int) erro
 
 	cmd := []byte(fmt.Sprintf("volume %v\r\n", level))
 
-	resp, err := p.SendCommand(ctx, p.Address, cmd)
+	return p.exec(ctx, cmd)
+}
+
+// StepVolume moves the volume of the projector by delta (on the same 0-100 scale as SetVolume) using
+// its increment commands, so that steps from several controllers at once don't race. Each increment
+// moves the volume by at least one of the projector's levels, and steps stop at the ends of the range.
+func (p *Projector) StepVolume(ctx context.Context, block string, delta int) error {
+	steps := delta / adcpConversion
+	switch {
+	case delta == 0:
+		return nil
+	case steps == 0 && delta > 0:
+		steps = 1
+	case steps == 0 && delta < 0:
+		steps = -1
+	}
+
+	cmd := []byte("volume --\r\n")
+	if steps > 0 {
+		cmd = []byte("volume ++\r\n")
+
+		// the projector goes past maxAdcp, so don't step above it
+		resp, err := p.send(ctx, volumeStatus)
+		if err != nil {
+			return err
+		}
+
+		volume, err := strconv.Atoi(resp)
+		if err != nil {
+			return err
+		}
+
+		if volume+steps > maxAdcp {
+			steps = maxAdcp - volume
+		}
+	} else {
+		steps = -steps
+	}
+
+	for i := 0; i < steps; i++ {
+		err := p.exec(ctx, cmd)
+		switch {
+		case errors.Is(err, ErrValue):
+			// already at the end of the range
+			return nil
+		case err != nil:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RampVolume fades the volume of the projector to level over duration, one projector level at a time,
+// or fewer if duration is too short to send every step.
+func (p *Projector) RampVolume(ctx context.Context, block string, level int, duration time.Duration) error {
+	vols, err := p.Volumes(ctx, []string{block})
 	if err != nil {
 		return err
 	}
 
-	if resp != "ok" {
-		return fmt.Errorf("unable to set volume to %v: %s", level, resp)
+	start := vols[""]
+
+	steps := normalToAdcpVolume(level) - normalToAdcpVolume(start)
+	if steps < 0 {
+		steps = -steps
+	}
+
+	if steps == 0 {
+		return nil
+	}
+
+	if duration/time.Duration(steps) < p.commandDelay() {
+		steps = int(duration / p.commandDelay())
+	}
+
+	if steps < 1 {
+		return p.SetVolume(ctx, block, level)
+	}
+
+	ticker := time.NewTicker(duration / time.Duration(steps))
+	defer ticker.Stop()
+
+	for i := 1; i <= steps; i++ {
+		if err := p.SetVolume(ctx, block, start+(level-start)*i/steps); err != nil {
+			return err
+		}
+
+		if i == steps {
+			break
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("unable to ramp volume: %w", ctx.Err())
+		}
 	}
 
 	return nil
@@ -51,19 +143,14 @@ func (p *Projector) SetVolume(ctx context.Context, block string, level int) erro
 func (p *Projector) Mutes(ctx context.Context, blocks []string) (map[string]bool, error) {
 	toReturn := make(map[string]bool)
 
-	resp, err := p.SendCommand(ctx, p.Address, muteStatus)
+	resp, err := p.send(ctx, muteStatus)
 	if err != nil {
 		return toReturn, err
 	}
 
-	var muted bool
-	switch resp {
-	case `"on"`:
-		muted = true
-	case `"off"`:
-		muted = false
-	default:
-		return toReturn, fmt.Errorf("unknown muted state '%s'", resp)
+	muted, err := parseOnOff("muted", resp)
+	if err != nil {
+		return toReturn, err
 	}
 
 	toReturn[""] = muted
@@ -81,16 +168,7 @@ func (p *Projector) SetMute(ctx context.Context, block string, muted bool) error
 	}
 
 	cmd := []byte(fmt.Sprintf("muting \"%s\"\r\n", str))
-	resp, err := p.SendCommand(ctx, p.Address, cmd)
-	if err != nil {
-		return err
-	}
-
-	if resp != "ok" {
-		return fmt.Errorf("unable to set muted state to %v: %s", muted, resp)
-	}
-
-	return nil
+	return p.exec(ctx, cmd)
 }
 
 // the volume level that the projectors put out is only really
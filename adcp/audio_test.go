@@ -0,0 +1,101 @@
+package adcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestVolume(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	is.NoErr(proj.SetPower(ctx, true))
+	defer proj.SetPower(ctx, false)
+
+	for _, vol := range []int{0, 30, 100} {
+		is.NoErr(proj.SetVolume(ctx, "", vol))
+
+		vols, err := proj.Volumes(ctx, []string{""})
+		is.NoErr(err)
+		is.Equal(vols[""], vol)
+	}
+}
+
+func TestMute(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	is.NoErr(proj.SetPower(ctx, true))
+	defer proj.SetPower(ctx, false)
+
+	for _, muted := range []bool{true, false} {
+		is.NoErr(proj.SetMute(ctx, "", muted))
+
+		mutes, err := proj.Mutes(ctx, []string{""})
+		is.NoErr(err)
+		is.Equal(mutes[""], muted)
+	}
+}
+
+func TestVolumeConversion(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(normalToAdcpVolume(-1), minAdcp)
+	is.Equal(normalToAdcpVolume(50), 25)
+	is.Equal(normalToAdcpVolume(101), maxAdcp)
+	is.Equal(adcpToNormalVolume(25), 50)
+}
+
+func TestStepVolume(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	is.NoErr(proj.SetPower(ctx, true))
+	defer proj.SetPower(ctx, false)
+
+	is.NoErr(proj.SetVolume(ctx, "", 30))
+
+	is.NoErr(proj.StepVolume(ctx, "", 4))
+	vols, err := proj.Volumes(ctx, []string{""})
+	is.NoErr(err)
+	is.Equal(vols[""], 34)
+
+	// smaller than one level still moves by one
+	is.NoErr(proj.StepVolume(ctx, "", -1))
+	vols, err = proj.Volumes(ctx, []string{""})
+	is.NoErr(err)
+	is.Equal(vols[""], 32)
+
+	// doesn't step past the top of the range
+	is.NoErr(proj.SetVolume(ctx, "", 98))
+	is.NoErr(proj.StepVolume(ctx, "", 10))
+	vols, err = proj.Volumes(ctx, []string{""})
+	is.NoErr(err)
+	is.Equal(vols[""], 100)
+}
+
+func TestRampVolume(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	is.NoErr(proj.SetPower(ctx, true))
+	defer proj.SetPower(ctx, false)
+
+	is.NoErr(proj.SetVolume(ctx, "", 30))
+	is.NoErr(proj.RampVolume(ctx, "", 40, time.Second))
+
+	vols, err := proj.Volumes(ctx, []string{""})
+	is.NoErr(err)
+	is.Equal(vols[""], 40)
+}
@@ -18,23 +18,28 @@ var (
 
 // GetBlank asks the projector if it is blanked or not and returns the result
 func (p *Projector) Blank(ctx context.Context) (bool, error) {
-	var blanked bool
-
-	resp, err := p.SendCommand(ctx, p.Address, BlankStatus)
+	resp, err := p.send(ctx, BlankStatus)
 	if err != nil {
 		return false, err
 	}
 
+	return parseOnOff("blanked", resp)
+}
+
+// parseOnOff parses a quoted "on" or "off" response.
+func parseOnOff(name, resp string) (bool, error) {
+	var on bool
+
 	switch resp {
 	case `"on"`:
-		blanked = true
+		on = true
 	case `"off"`:
-		blanked = false
+		on = false
 	default:
-		return false, fmt.Errorf("unknown blanked state '%s'", resp)
+		return false, fmt.Errorf("unknown %s state '%s'", name, resp)
 	}
 
-	return blanked, nil
+	return on, nil
 }
 
 // SetBlank tells the projector to blank or unblank itself
@@ -44,14 +49,5 @@ func (p *Projector) SetBlank(ctx context.Context, blanked bool) error {
 		cmd = Blank
 	}
 
-	resp, err := p.SendCommand(ctx, p.Address, cmd)
-	if err != nil {
-		return err
-	}
-
-	if resp != "ok" {
-		return fmt.Errorf("unable to set blanked state to %v: %s", blanked, resp)
-	}
-
-	return nil
+	return p.exec(ctx, cmd)
 }
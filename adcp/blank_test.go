@@ -0,0 +1,37 @@
+package adcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestBlank(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	is.NoErr(proj.SetPower(ctx, true))
+	defer proj.SetPower(ctx, false)
+
+	for _, blanked := range []bool{true, false} {
+		is.NoErr(proj.SetBlank(ctx, blanked))
+
+		b, err := proj.Blank(ctx)
+		is.NoErr(err)
+		is.Equal(b, blanked)
+	}
+}
+
+func TestBlankStandby(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	is.NoErr(proj.SetPower(ctx, false))
+	is.True(proj.SetBlank(ctx, true) != nil)
+}
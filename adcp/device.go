@@ -2,8 +2,11 @@ package adcp
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +19,47 @@ type Projector struct {
 	poolInit sync.Once
 	pool     *pooled.Pool
 	Address  string
+
+	// Port is the port ADCP is served on, if Address doesn't include one. Defaults to DefaultPort if not set.
+	Port int
+
+	// DialTimeout limits how long connecting to the projector can take. Defaults to DefaultDialTimeout.
+	DialTimeout time.Duration
+
+	// HandshakeTimeout limits how long reading the projector's greeting, and answering
+	// its authentication challenge, can take. Defaults to DefaultHandshakeTimeout.
+	HandshakeTimeout time.Duration
+
+	// WriteTimeout and ReadTimeout limit how long sending a command and reading its
+	// response can take. Both default to DefaultIOTimeout.
+	WriteTimeout time.Duration
+	ReadTimeout  time.Duration
+
+	// PoolTTL is how long an idle connection is kept open. Defaults to DefaultPoolTTL.
+	PoolTTL time.Duration
+
+	// CommandDelay is the minimum time between the end of one command to the projector and the start of the next.
+	// Defaults to DefaultCommandDelay.
+	CommandDelay time.Duration
+
+	// Password is the password used to authenticate with projectors that have
+	// network authentication enabled. It is ignored by projectors that don't.
+	Password string
+
+	// WaitForPower makes SetPower wait until the projector has finished starting up or
+	// cooling down. Commands sent in the meantime wait for it to finish, and commands the
+	// projector rejects because it is mid-transition are resent once it is done.
+	WaitForPower bool
+
+	authMu  sync.Mutex
+	authErr error
+
+	powerMu    sync.Mutex
+	transition chan struct{}
+
+	// lastSent is when the last command to the projector finished
+	lastMu   sync.Mutex
+	lastSent time.Time
 }
 
 const (
@@ -23,68 +67,285 @@ const (
 	CR = '\r'
 	// LF is a line feed
 	LF = '\n'
+
+	// DefaultPort is the port projectors serve ADCP on by default
+	DefaultPort = 53595
+
+	// DefaultDialTimeout is the default Projector.DialTimeout
+	DefaultDialTimeout = 10 * time.Second
+
+	// DefaultHandshakeTimeout is the default Projector.HandshakeTimeout
+	DefaultHandshakeTimeout = 5 * time.Second
+
+	// DefaultIOTimeout is the default Projector.WriteTimeout and Projector.ReadTimeout
+	DefaultIOTimeout = 3 * time.Second
+
+	// DefaultPoolTTL is the default Projector.PoolTTL
+	DefaultPoolTTL = 45 * time.Second
+
+	// DefaultCommandDelay is the default Projector.CommandDelay
+	DefaultCommandDelay = 400 * time.Millisecond
 )
 
-func getConnection(key interface{}) (pooled.Conn, error) {
+// orDefault returns d, or def if d isn't set.
+func orDefault(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+
+	return d
+}
+
+// dialAddress returns the host:port to dial for address, which may include its own port.
+func (p *Projector) dialAddress(address string) string {
+	if _, _, err := net.SplitHostPort(address); err == nil {
+		return address
+	}
+
+	port := p.Port
+	if port == 0 {
+		port = DefaultPort
+	}
+
+	return net.JoinHostPort(address, strconv.Itoa(port))
+}
+
+func (p *Projector) getConnection(key interface{}) (pooled.Conn, error) {
 	address, ok := key.(string)
 	if !ok {
 		return nil, fmt.Errorf("key must be a string")
 	}
 
-	conn, err := net.DialTimeout("tcp", address+":53595", 10*time.Second)
+	// forget why the last connection failed, so that it isn't blamed for this one failing
+	p.setAuthErr(nil)
+
+	conn, err := net.DialTimeout("tcp", p.dialAddress(address), orDefault(p.DialTimeout, DefaultDialTimeout))
 	if err != nil {
 		return nil, err
 	}
 
-	// read the NOKEY line
+	// read the NOKEY line, or the random key if authentication is enabled
 	pconn := pooled.Wrap(conn)
-	b, err := pconn.ReadUntil(LF, 5*time.Second)
+	b, err := pconn.ReadUntil(LF, orDefault(p.HandshakeTimeout, DefaultHandshakeTimeout))
 	if err != nil {
 		conn.Close()
 		return nil, err
 	}
 
-	if strings.TrimSpace(string(b)) != "NOKEY" {
-		conn.Close()
-		return nil, fmt.Errorf("unexpected message when opening connection: %s", b)
+	banner := strings.TrimSpace(string(b))
+	if banner != "NOKEY" {
+		if err := p.authenticate(pconn, banner); err != nil {
+			conn.Close()
+			p.setAuthErr(err)
+			return nil, err
+		}
 	}
 
 	return pconn, nil
 }
 
+// authenticate answers the projector's challenge with the sha256 hash of
+// the random key it sent followed by the password.
+func (p *Projector) authenticate(conn pooled.Conn, key string) error {
+	if p.Password == "" {
+		return fmt.Errorf("%w: projector requires a password", ErrAuth)
+	}
+
+	hash := sha256.Sum256([]byte(key + p.Password))
+	cmd := []byte(hex.EncodeToString(hash[:]) + "\r\n")
+
+	timeout := orDefault(p.HandshakeTimeout, DefaultHandshakeTimeout)
+
+	conn.SetWriteDeadline(time.Now().Add(timeout))
+	if _, err := conn.Write(cmd); err != nil {
+		return fmt.Errorf("unable to send authentication: %w", err)
+	}
+
+	b, err := conn.ReadUntil(LF, timeout)
+	if err != nil {
+		return fmt.Errorf("unable to read authentication response: %w", err)
+	}
+
+	switch resp := strings.TrimSpace(string(b)); resp {
+	case "OK":
+		return nil
+	case "err_auth":
+		return ErrAuth
+	default:
+		return fmt.Errorf("unexpected message when authenticating: %s", resp)
+	}
+}
+
+func (p *Projector) setAuthErr(err error) {
+	p.authMu.Lock()
+	defer p.authMu.Unlock()
+
+	p.authErr = err
+}
+
+func (p *Projector) getAuthErr() error {
+	p.authMu.Lock()
+	defer p.authMu.Unlock()
+
+	return p.authErr
+}
+
 // SendCommand sends the byte array to the desired address of the projector
 func (p *Projector) SendCommand(ctx context.Context, addr string, cmd []byte) (string, error) {
+	if !p.WaitForPower {
+		return p.sendCommand(ctx, addr, cmd)
+	}
+
+	if err := p.waitForTransition(ctx); err != nil {
+		return "", err
+	}
+
+	return p.sendWhenReady(ctx, addr, cmd)
+}
+
+// send sends cmd to the projector, returning a *CommandError if it answers with an error.
+func (p *Projector) send(ctx context.Context, cmd []byte) (string, error) {
+	resp, err := p.SendCommand(ctx, p.Address, cmd)
+	if err != nil {
+		return "", err
+	}
+
+	if err := checkResponse(cmd, resp); err != nil {
+		return "", err
+	}
+
+	return resp, nil
+}
+
+// exec sends cmd to the projector, returning an error unless it answers with "ok".
+func (p *Projector) exec(ctx context.Context, cmd []byte) error {
+	resp, err := p.SendCommand(ctx, p.Address, cmd)
+	if err != nil {
+		return err
+	}
+
+	return checkOK(cmd, resp)
+}
+
+// sendWhenReady sends cmd, and if the projector rejects it because it is starting up
+// or cooling down, sends it again once the projector has finished.
+func (p *Projector) sendWhenReady(ctx context.Context, addr string, cmd []byte) (string, error) {
+	resp, err := p.sendCommand(ctx, addr, cmd)
+	if err != nil || resp != "err_inactive" {
+		return resp, err
+	}
+
+	state, err := p.PowerState(ctx)
+	switch {
+	case err != nil:
+		return "", err
+	case !state.Transitioning():
+		return resp, nil
+	}
+
+	if _, err := p.waitForSteadyState(ctx); err != nil {
+		return "", err
+	}
+
+	return p.sendCommand(ctx, addr, cmd)
+}
+
+// sendCommand sends cmd without waiting for power transitions.
+func (p *Projector) sendCommand(ctx context.Context, addr string, cmd []byte) (string, error) {
+	var resp string
+	err := p.do(ctx, addr, func(conn pooled.Conn) error {
+		var err error
+		resp, err = p.command(conn, cmd)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resp, nil
+}
+
+// do calls f with a pooled connection to addr.
+func (p *Projector) do(ctx context.Context, addr string, f func(conn pooled.Conn) error) error {
 	p.poolInit.Do(func() {
 		// create the pool
-		p.pool = pooled.NewPool(45*time.Second, 400*time.Millisecond, getConnection)
+		p.pool = pooled.NewPool(orDefault(p.PoolTTL, DefaultPoolTTL), p.commandDelay(), p.getConnection)
 	})
 
-	var resp []byte
 	err := p.pool.Do(addr, func(conn pooled.Conn) error {
-		conn.SetWriteDeadline(time.Now().Add(3 * time.Second))
-
-		n, err := conn.Write(cmd)
-		switch {
-		case err != nil:
+		// the pool doesn't wait between commands itself
+		if err := p.throttle(ctx); err != nil {
 			return err
-		case n != len(cmd):
-			return fmt.Errorf("wrote %v/%v bytes of command 0x%x", n, len(cmd), cmd)
 		}
 
-		resp = make([]byte, 5)
-
-		resp, err = conn.ReadUntil(LF, 3*time.Second)
-		if err != nil {
-			return err
+		defer p.sent()
+		return f(conn)
+	})
+	if err != nil {
+		// the pool doesn't wrap errors from opening a connection,
+		// so authentication errors have to be surfaced separately
+		if authErr := p.getAuthErr(); authErr != nil {
+			return authErr
 		}
 
-		conn.Log().Debugf("Response from command: 0x%x", resp)
+		return err
+	}
+
+	return nil
+}
+
+// commandDelay returns the minimum time between commands.
+func (p *Projector) commandDelay() time.Duration {
+	return orDefault(p.CommandDelay, DefaultCommandDelay)
+}
 
+// throttle waits until CommandDelay has passed since the last command finished.
+func (p *Projector) throttle(ctx context.Context) error {
+	p.lastMu.Lock()
+	wait := time.Until(p.lastSent.Add(p.commandDelay()))
+	p.lastMu.Unlock()
+
+	if wait <= 0 {
 		return nil
-	})
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("unable to wait to send command: %w", ctx.Err())
+	}
+}
+
+// sent records that a command just finished.
+func (p *Projector) sent() {
+	p.lastMu.Lock()
+	defer p.lastMu.Unlock()
+
+	p.lastSent = time.Now()
+}
+
+// command sends cmd on conn and returns the projector's response.
+func (p *Projector) command(conn pooled.Conn, cmd []byte) (string, error) {
+	conn.SetWriteDeadline(time.Now().Add(orDefault(p.WriteTimeout, DefaultIOTimeout)))
+
+	n, err := conn.Write(cmd)
+	switch {
+	case err != nil:
+		return "", err
+	case n != len(cmd):
+		return "", fmt.Errorf("wrote %v/%v bytes of command 0x%x", n, len(cmd), cmd)
+	}
+
+	resp, err := conn.ReadUntil(LF, orDefault(p.ReadTimeout, DefaultIOTimeout))
 	if err != nil {
 		return "", err
 	}
 
+	conn.Log().Debugf("Response from command: 0x%x", resp)
+
 	return strings.TrimSpace(string(resp)), nil
 }
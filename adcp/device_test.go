@@ -0,0 +1,156 @@
+package adcp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/byuoitav/sony/adcp/adcptest"
+	"github.com/matryer/is"
+)
+
+var (
+	proj *Projector
+	sim  *adcptest.Server
+)
+
+func TestMain(m *testing.M) {
+	sim = adcptest.NewServer()
+
+	// the simulator doesn't need the time between commands a real projector does
+	proj = &Projector{
+		Address:      sim.Host(),
+		Port:         sim.Port(),
+		CommandDelay: 10 * time.Millisecond,
+	}
+
+	code := m.Run()
+	sim.Close()
+	os.Exit(code)
+}
+
+func TestAuthentication(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s := adcptest.NewServer()
+	defer s.Close()
+
+	s.SetPassword("Projector")
+
+	p := &Projector{
+		Address:  s.Host(),
+		Port:     s.Port(),
+		Password: "Projector",
+	}
+
+	_, err := p.Power(ctx)
+	is.NoErr(err)
+
+	p = &Projector{
+		Address:  s.Host(),
+		Port:     s.Port(),
+		Password: "wrong",
+	}
+
+	_, err = p.Power(ctx)
+	is.True(errors.Is(err, ErrAuth))
+
+	p = &Projector{
+		Address: s.Host(),
+		Port:    s.Port(),
+	}
+
+	_, err = p.Power(ctx)
+	is.True(errors.Is(err, ErrAuth))
+
+	// the authentication failure isn't blamed for failing to connect later
+	s.Close()
+
+	_, err = p.Power(ctx)
+	is.True(err != nil)
+	is.True(!errors.Is(err, ErrAuth))
+}
+
+func TestAddressWithPort(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	p := &Projector{
+		Address: net.JoinHostPort(sim.Host(), strconv.Itoa(sim.Port())),
+		Port:    1, // ignored, since the address has a port
+	}
+
+	_, err := p.Power(ctx)
+	is.NoErr(err)
+}
+
+func TestReadTimeout(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// a projector that greets but never answers
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	is.NoErr(err)
+	defer lis.Close()
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+
+			conn.Write([]byte("NOKEY\r\n"))
+			defer conn.Close()
+		}
+	}()
+
+	p := &Projector{
+		Address:     lis.Addr().String(),
+		ReadTimeout: 100 * time.Millisecond,
+	}
+
+	start := time.Now()
+	_, err = p.Power(ctx)
+	is.True(err != nil)
+	is.True(time.Since(start) < time.Second)
+}
+
+func TestCommandDelay(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	p := &Projector{
+		Address:      sim.Host(),
+		Port:         sim.Port(),
+		CommandDelay: 300 * time.Millisecond,
+	}
+
+	_, err := p.Power(ctx)
+	is.NoErr(err)
+
+	start := time.Now()
+	_, err = p.Power(ctx)
+	is.NoErr(err)
+	is.True(time.Since(start) >= p.CommandDelay)
+
+	// waiting for the delay gives up with ctx
+	shortCtx, shortCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer shortCancel()
+
+	_, err = p.Power(shortCtx)
+	is.True(errors.Is(err, context.DeadlineExceeded))
+}
@@ -3,23 +3,85 @@ package adcp
 import (
 	"errors"
 	"fmt"
+	"strings"
+)
+
+// Errors the projector answers commands with, for use with errors.Is
+var (
+	ErrCommand   = errors.New("command format error")
+	ErrOption    = errors.New("command option error")
+	ErrInactive  = errors.New("command is temporarily invalid")
+	ErrValue     = errors.New("value for command is out of range")
+	ErrAuth      = errors.New("network authentication error")
+	ErrInternal1 = errors.New("internal communication error 1 of the projector")
+	ErrInternal2 = errors.New("internal communication error 2 of the projector")
+
+	// ErrUnexpectedResponse is returned when the projector answers a command with something other than what was expected
+	ErrUnexpectedResponse = errors.New("unexpected response")
 )
 
 var responseError = map[string]error{
 	"ok":            nil,
-	"err_cmd":       errors.New("command format error"),
-	"err_option":    errors.New("command option error"),
-	"err_inactive":  errors.New("command is temporarily invalid"),
-	"err_val":       errors.New("value for command is out of range"),
-	"err_auth":      errors.New("network authentication error"),
-	"err_internal1": errors.New("internal communication error 1 of the projector"),
-	"err_internal2": errors.New("internal communication error 2 of the projector"),
+	"err_cmd":       ErrCommand,
+	"err_option":    ErrOption,
+	"err_inactive":  ErrInactive,
+	"err_val":       ErrValue,
+	"err_auth":      ErrAuth,
+	"err_internal1": ErrInternal1,
+	"err_internal2": ErrInternal2,
 }
 
+// ResponseError returns the error for a response to a command that should have been answered with "ok"
 func ResponseError(resp string) error {
 	if err, ok := responseError[resp]; ok {
 		return err
 	}
 
-	return fmt.Errorf("unknown response error %q", resp)
+	return fmt.Errorf("%w %q", ErrUnexpectedResponse, resp)
+}
+
+// CommandError is returned when the projector answers a command with an error
+type CommandError struct {
+	// Command is the command that was sent, ie "volume 25"
+	Command string
+
+	// Response is what the projector answered with, ie "err_val"
+	Response string
+
+	// Err is one of the sentinel errors in this package
+	Err error
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Command, e.Err)
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// checkOK returns a *CommandError unless resp is "ok".
+func checkOK(cmd []byte, resp string) error {
+	if resp == "ok" {
+		return nil
+	}
+
+	return &CommandError{
+		Command:  strings.TrimSpace(string(cmd)),
+		Response: resp,
+		Err:      ResponseError(resp),
+	}
+}
+
+// checkResponse returns a *CommandError if resp is an error response to cmd.
+func checkResponse(cmd []byte, resp string) error {
+	if !strings.HasPrefix(resp, "err_") {
+		return nil
+	}
+
+	return &CommandError{
+		Command:  strings.TrimSpace(string(cmd)),
+		Response: resp,
+		Err:      ResponseError(resp),
+	}
 }
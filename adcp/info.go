@@ -42,7 +42,7 @@ func (p *Projector) Info(ctx context.Context) (interface{}, error) {
 	var info HardwareInfo
 
 	// model name
-	resp, err := p.SendCommand(ctx, p.Address, modelName)
+	resp, err := p.send(ctx, modelName)
 	if err != nil {
 		return info, err
 	}
@@ -50,7 +50,7 @@ func (p *Projector) Info(ctx context.Context) (interface{}, error) {
 	info.ModelName = strings.Trim(resp, "\"")
 
 	// ip address
-	resp, err = p.SendCommand(ctx, p.Address, ipAddr)
+	resp, err = p.send(ctx, ipAddr)
 	if err != nil {
 		return info, err
 	}
@@ -58,7 +58,7 @@ func (p *Projector) Info(ctx context.Context) (interface{}, error) {
 	info.IPAddress = strings.Trim(resp, "\"")
 
 	// gateway
-	resp, err = p.SendCommand(ctx, p.Address, gateway)
+	resp, err = p.send(ctx, gateway)
 	if err != nil {
 		return info, err
 	}
@@ -66,14 +66,14 @@ func (p *Projector) Info(ctx context.Context) (interface{}, error) {
 	info.Gateway = strings.Trim(resp, "\"")
 
 	// dns
-	resp, err = p.SendCommand(ctx, p.Address, dns)
+	resp, err = p.send(ctx, dns)
 	if err != nil {
 		return info, err
 	}
 
 	info.DNS = append(info.DNS, strings.Trim(resp, "\""))
 
-	resp, err = p.SendCommand(ctx, p.Address, dns2)
+	resp, err = p.send(ctx, dns2)
 	if err != nil {
 		return info, err
 	}
@@ -81,7 +81,7 @@ func (p *Projector) Info(ctx context.Context) (interface{}, error) {
 	info.DNS = append(info.DNS, strings.Trim(resp, "\""))
 
 	// mac address
-	resp, err = p.SendCommand(ctx, p.Address, macAddr)
+	resp, err = p.send(ctx, macAddr)
 	if err != nil {
 		return info, err
 	}
@@ -89,7 +89,7 @@ func (p *Projector) Info(ctx context.Context) (interface{}, error) {
 	info.MACAddress = strings.Trim(resp, "\"")
 
 	// serial number
-	resp, err = p.SendCommand(ctx, p.Address, serialNum)
+	resp, err = p.send(ctx, serialNum)
 	if err != nil {
 		return info, err
 	}
@@ -97,7 +97,7 @@ func (p *Projector) Info(ctx context.Context) (interface{}, error) {
 	info.SerialNumber = strings.Trim(resp, "\"")
 
 	// filter status
-	resp, err = p.SendCommand(ctx, p.Address, filter)
+	resp, err = p.send(ctx, filter)
 	if err != nil {
 		return info, err
 	}
@@ -105,7 +105,7 @@ func (p *Projector) Info(ctx context.Context) (interface{}, error) {
 	info.FilterStatus = strings.Trim(resp, "\"")
 
 	// power status
-	resp, err = p.SendCommand(ctx, p.Address, powerStatus)
+	resp, err = p.send(ctx, powerStatus)
 	if err != nil {
 		return info, err
 	}
@@ -113,7 +113,7 @@ func (p *Projector) Info(ctx context.Context) (interface{}, error) {
 	info.PowerStatus = strings.Trim(resp, "\"")
 
 	// warnings
-	resp, err = p.SendCommand(ctx, p.Address, warnings)
+	resp, err = p.send(ctx, warnings)
 	if err != nil {
 		return info, err
 	}
@@ -124,7 +124,7 @@ func (p *Projector) Info(ctx context.Context) (interface{}, error) {
 	}
 
 	// errors
-	resp, err = p.SendCommand(ctx, p.Address, cmdErrors)
+	resp, err = p.send(ctx, cmdErrors)
 	if err != nil {
 		return info, err
 	}
@@ -135,7 +135,7 @@ func (p *Projector) Info(ctx context.Context) (interface{}, error) {
 	}
 
 	// timer info
-	resp, err = p.SendCommand(ctx, p.Address, timer)
+	resp, err = p.send(ctx, timer)
 	if err != nil {
 		return info, err
 	}
@@ -0,0 +1,36 @@
+package adcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestInfo(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	info, err := proj.Info(ctx)
+	is.NoErr(err)
+
+	hw, ok := info.(HardwareInfo)
+	is.True(ok)
+	is.Equal(hw.ModelName, sim.State().ModelName)
+	is.Equal(len(hw.DNS), 2)
+	is.Equal(len(hw.TimerInfo), 2)
+
+	t.Logf("%+v", info)
+}
+
+func TestHealth(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	is.NoErr(proj.Healthy(ctx))
+}
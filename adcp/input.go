@@ -17,7 +17,7 @@ var (
 // GetAudioVideoInputs returns the current input that the projector is set to
 func (p *Projector) AudioVideoInputs(ctx context.Context) (map[string]string, error) {
 	toReturn := make(map[string]string)
-	resp, err := p.SendCommand(ctx, p.Address, InputStatus)
+	resp, err := p.send(ctx, InputStatus)
 	if err != nil {
 		return toReturn, err
 	}
@@ -29,21 +29,13 @@ func (p *Projector) AudioVideoInputs(ctx context.Context) (map[string]string, er
 // SetAudioVideoInput sets the current input of the projector to the given input
 func (p *Projector) SetAudioVideoInput(ctx context.Context, output, input string) error {
 	cmd := []byte(fmt.Sprintf("input \"%s\"\r\n", input))
-	resp, err := p.SendCommand(ctx, p.Address, cmd)
-	if err != nil {
-		return err
-	}
-	if resp != "ok" {
-		return fmt.Errorf("unable to set input to %v: %s", input, resp)
-	}
-
-	return nil
+	return p.exec(ctx, cmd)
 }
 
 // ActiveSignal checks to see if the projector has an active input signal and returns the result
 func (p *Projector) ActiveSignal(ctx context.Context, port string) (bool, error) {
 	var active bool
-	resp, err := p.SendCommand(ctx, p.Address, ActiveSignal)
+	resp, err := p.send(ctx, ActiveSignal)
 	if err != nil {
 		return false, err
 	}
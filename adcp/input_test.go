@@ -0,0 +1,61 @@
+package adcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestAudioVideoInput(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	is.NoErr(proj.SetPower(ctx, true))
+	defer proj.SetPower(ctx, false)
+
+	is.NoErr(proj.SetAudioVideoInput(ctx, "", "hdmi2"))
+
+	inputs, err := proj.AudioVideoInputs(ctx)
+	is.NoErr(err)
+	is.Equal(inputs[""], "hdmi2")
+
+	active, err := proj.ActiveSignal(ctx, "hdmi2")
+	is.NoErr(err)
+	is.True(!active)
+
+	is.NoErr(proj.SetAudioVideoInput(ctx, "", "hdmi1"))
+
+	active, err = proj.ActiveSignal(ctx, "hdmi1")
+	is.NoErr(err)
+	is.True(active)
+}
+
+func TestCommandError(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	is.NoErr(proj.SetPower(ctx, true))
+	defer proj.SetPower(ctx, false)
+
+	sim.SetError("input", "err_val")
+	defer sim.ClearError("input")
+
+	err := proj.SetAudioVideoInput(ctx, "", "hdmi2")
+	is.True(errors.Is(err, ErrValue))
+
+	var cmdErr *CommandError
+	is.True(errors.As(err, &cmdErr))
+	is.Equal(cmdErr.Command, `input "hdmi2"`)
+	is.Equal(cmdErr.Response, "err_val")
+
+	// queries don't return error responses as data
+	_, err = proj.AudioVideoInputs(ctx)
+	is.True(errors.Is(err, ErrValue))
+}
@@ -0,0 +1,91 @@
+package adcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	// MaxLensSteps is the most steps a single call to one of the lens stepping methods moves the lens,
+	// so that a runaway loop can't drive it into its stops
+	MaxLensSteps = 50
+
+	// LensMemories is the number of lens memories RecallLensMemory and SaveLensMemory accept
+	LensMemories = 6
+)
+
+// StepLensZoom zooms the lens by steps. Positive steps make the picture larger, and negative steps make it smaller.
+// It returns how many steps the lens moved, with the same sign as steps. That is fewer than steps once the lens
+// reaches the end of its travel, so there's no point stepping it further in that direction.
+func (p *Projector) StepLensZoom(ctx context.Context, steps int) (int, error) {
+	return p.stepLens(ctx, "lens_zoom", steps)
+}
+
+// StepLensFocus focuses the lens by steps. Positive steps focus farther, and negative steps focus nearer.
+// Like StepLensZoom, it returns how many steps the lens moved.
+func (p *Projector) StepLensFocus(ctx context.Context, steps int) (int, error) {
+	return p.stepLens(ctx, "lens_focus", steps)
+}
+
+// StepLensShiftH shifts the lens horizontally by steps. Positive steps shift the picture right, and negative steps shift it left.
+// Like StepLensZoom, it returns how many steps the lens moved.
+func (p *Projector) StepLensShiftH(ctx context.Context, steps int) (int, error) {
+	return p.stepLens(ctx, "lens_shift_h", steps)
+}
+
+// StepLensShiftV shifts the lens vertically by steps. Positive steps shift the picture up, and negative steps shift it down.
+// Like StepLensZoom, it returns how many steps the lens moved.
+func (p *Projector) StepLensShiftV(ctx context.Context, steps int) (int, error) {
+	return p.stepLens(ctx, "lens_shift_v", steps)
+}
+
+// RecallLensMemory moves the lens to the position saved in lens memory n, from 1 to LensMemories
+func (p *Projector) RecallLensMemory(ctx context.Context, n int) error {
+	return p.lensMemory(ctx, "load", n)
+}
+
+// SaveLensMemory saves the lens' current position in lens memory n, from 1 to LensMemories
+func (p *Projector) SaveLensMemory(ctx context.Context, n int) error {
+	return p.lensMemory(ctx, "save", n)
+}
+
+// stepLens moves the lens one step at a time, no faster than the projector's CommandDelay, and returns
+// how many steps it moved. Steps stop at the end of the lens' travel. It returns an error wrapping ErrValue
+// without moving the lens if steps is more than MaxLensSteps in either direction.
+func (p *Projector) stepLens(ctx context.Context, name string, steps int) (int, error) {
+	if steps > MaxLensSteps || steps < -MaxLensSteps {
+		return 0, fmt.Errorf("%s %d steps (max %d): %w", name, steps, MaxLensSteps, ErrValue)
+	}
+
+	cmd, dir := []byte(name+" ++\r\n"), 1
+	if steps < 0 {
+		cmd, dir = []byte(name+" --\r\n"), -1
+		steps = -steps
+	}
+
+	if steps == 0 {
+		return 0, nil
+	}
+
+	for i := 0; i < steps; i++ {
+		err := p.exec(ctx, cmd)
+		switch {
+		case errors.Is(err, ErrValue):
+			// at the end of its travel
+			return i * dir, nil
+		case err != nil:
+			return i * dir, err
+		}
+	}
+
+	return steps * dir, nil
+}
+
+func (p *Projector) lensMemory(ctx context.Context, action string, n int) error {
+	if n < 1 || n > LensMemories {
+		return fmt.Errorf("lens memory %d: %w", n, ErrValue)
+	}
+
+	return p.exec(ctx, []byte(fmt.Sprintf("lens_memory \"%s\" %d\r\n", action, n)))
+}
@@ -0,0 +1,98 @@
+package adcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/byuoitav/sony/adcp/adcptest"
+	"github.com/matryer/is"
+)
+
+func TestStepLens(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	p := &Projector{
+		Address:      sim.Host(),
+		Port:         sim.Port(),
+		CommandDelay: 100 * time.Millisecond,
+	}
+
+	is.NoErr(p.SetPower(ctx, true))
+	defer p.SetPower(ctx, false)
+
+	sim.SetState(func(s *adcptest.State) {
+		s.Lens = adcptest.LensPosition{Zoom: 50, Focus: 50, ShiftH: 50, ShiftV: 50}
+	})
+
+	start := time.Now()
+	moved, err := p.StepLensZoom(ctx, 3)
+	is.NoErr(err)
+	is.Equal(moved, 3)
+	is.True(time.Since(start) >= 2*p.CommandDelay) // steps are paced
+
+	moved, err = p.StepLensFocus(ctx, -2)
+	is.NoErr(err)
+	is.Equal(moved, -2)
+
+	moved, err = p.StepLensShiftH(ctx, 0)
+	is.NoErr(err)
+	is.Equal(moved, 0)
+
+	lens := sim.State().Lens
+	is.Equal(lens.Zoom, 53)
+	is.Equal(lens.Focus, 48)
+	is.Equal(lens.ShiftH, 50)
+
+	// steps stop at the end of the lens' travel
+	sim.SetState(func(s *adcptest.State) {
+		s.Lens.ShiftV = adcptest.MaxLensPosition - 1
+	})
+
+	moved, err = p.StepLensShiftV(ctx, 5)
+	is.NoErr(err)
+	is.Equal(moved, 1)
+	is.Equal(sim.State().Lens.ShiftV, adcptest.MaxLensPosition)
+
+	// too many steps are rejected without moving the lens
+	moved, err = p.StepLensShiftV(ctx, -MaxLensSteps-1)
+	is.True(errors.Is(err, ErrValue))
+	is.Equal(moved, 0)
+	is.Equal(sim.State().Lens.ShiftV, adcptest.MaxLensPosition)
+}
+
+func TestLensMemory(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	is.NoErr(proj.SetPower(ctx, true))
+	defer proj.SetPower(ctx, false)
+
+	saved := adcptest.LensPosition{Zoom: 20, Focus: 30, ShiftH: 40, ShiftV: 60}
+	sim.SetState(func(s *adcptest.State) {
+		s.Lens = saved
+	})
+
+	is.NoErr(proj.SaveLensMemory(ctx, 2))
+	_, err := proj.StepLensZoom(ctx, 1)
+	is.NoErr(err)
+	is.NoErr(proj.RecallLensMemory(ctx, 2))
+	is.Equal(sim.State().Lens, saved)
+
+	is.True(errors.Is(proj.RecallLensMemory(ctx, 0), ErrValue))
+	is.True(errors.Is(proj.SaveLensMemory(ctx, LensMemories+1), ErrValue))
+
+	// nothing is saved in memory 5
+	err = proj.RecallLensMemory(ctx, 5)
+	is.True(errors.Is(err, ErrValue))
+
+	var cmdErr *CommandError
+	is.True(errors.As(err, &cmdErr))
+	is.Equal(cmdErr.Command, `lens_memory "load" 5`)
+}
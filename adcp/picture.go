@@ -0,0 +1,133 @@
+package adcp
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// the range of the numeric picture adjustments (brightness, contrast, etc.)
+const (
+	MinPictureLevel = 0
+	MaxPictureLevel = 100
+)
+
+// PictureMode returns the projector's picture mode (ie, "standard" or "cinema")
+func (p *Projector) PictureMode(ctx context.Context) (string, error) {
+	return p.getString(ctx, "picture_mode")
+}
+
+// SetPictureMode sets the projector's picture mode. The modes available depend on the model.
+func (p *Projector) SetPictureMode(ctx context.Context, mode string) error {
+	return p.setString(ctx, "picture_mode", mode)
+}
+
+// Brightness returns the projector's brightness, from 0-100
+func (p *Projector) Brightness(ctx context.Context) (int, error) {
+	return p.getLevel(ctx, "brightness")
+}
+
+// SetBrightness sets the projector's brightness, from 0-100
+func (p *Projector) SetBrightness(ctx context.Context, level int) error {
+	return p.setLevel(ctx, "brightness", level)
+}
+
+// Contrast returns the projector's contrast, from 0-100
+func (p *Projector) Contrast(ctx context.Context) (int, error) {
+	return p.getLevel(ctx, "contrast")
+}
+
+// SetContrast sets the projector's contrast, from 0-100
+func (p *Projector) SetContrast(ctx context.Context, level int) error {
+	return p.setLevel(ctx, "contrast", level)
+}
+
+// Color returns the projector's color saturation, from 0-100
+func (p *Projector) Color(ctx context.Context) (int, error) {
+	return p.getLevel(ctx, "color")
+}
+
+// SetColor sets the projector's color saturation, from 0-100
+func (p *Projector) SetColor(ctx context.Context, level int) error {
+	return p.setLevel(ctx, "color", level)
+}
+
+// Hue returns the projector's hue, from 0-100
+func (p *Projector) Hue(ctx context.Context) (int, error) {
+	return p.getLevel(ctx, "hue")
+}
+
+// SetHue sets the projector's hue, from 0-100
+func (p *Projector) SetHue(ctx context.Context, level int) error {
+	return p.setLevel(ctx, "hue", level)
+}
+
+// Sharpness returns the projector's sharpness, from 0-100
+func (p *Projector) Sharpness(ctx context.Context) (int, error) {
+	return p.getLevel(ctx, "sharpness")
+}
+
+// SetSharpness sets the projector's sharpness, from 0-100
+func (p *Projector) SetSharpness(ctx context.Context, level int) error {
+	return p.setLevel(ctx, "sharpness", level)
+}
+
+// ColorTemp returns the projector's color temperature (ie, "middle" or "d65")
+func (p *Projector) ColorTemp(ctx context.Context) (string, error) {
+	return p.getString(ctx, "color_temp")
+}
+
+// SetColorTemp sets the projector's color temperature. The temperatures available depend on the model.
+func (p *Projector) SetColorTemp(ctx context.Context, temp string) error {
+	return p.setString(ctx, "color_temp", temp)
+}
+
+// GammaCorrection returns the projector's gamma correction (ie, "2.2" or "off")
+func (p *Projector) GammaCorrection(ctx context.Context) (string, error) {
+	return p.getString(ctx, "gamma_correction")
+}
+
+// SetGammaCorrection sets the projector's gamma correction. The values available depend on the model.
+func (p *Projector) SetGammaCorrection(ctx context.Context, gamma string) error {
+	return p.setString(ctx, "gamma_correction", gamma)
+}
+
+func (p *Projector) getLevel(ctx context.Context, name string) (int, error) {
+	resp, err := p.send(ctx, []byte(name+" ?\r\n"))
+	if err != nil {
+		return 0, err
+	}
+
+	level, err := strconv.Atoi(resp)
+	if err != nil {
+		return 0, fmt.Errorf("%w to %s: %q", ErrUnexpectedResponse, name, resp)
+	}
+
+	return level, nil
+}
+
+func (p *Projector) setLevel(ctx context.Context, name string, level int) error {
+	if level < MinPictureLevel || level > MaxPictureLevel {
+		return fmt.Errorf("%s %d: %w", name, level, ErrValue)
+	}
+
+	return p.exec(ctx, []byte(fmt.Sprintf("%s %d\r\n", name, level)))
+}
+
+func (p *Projector) getString(ctx context.Context, name string) (string, error) {
+	resp, err := p.send(ctx, []byte(name+" ?\r\n"))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Trim(resp, "\""), nil
+}
+
+func (p *Projector) setString(ctx context.Context, name, value string) error {
+	if value == "" || strings.ContainsAny(value, "\"\r\n") {
+		return fmt.Errorf("%s %q: %w", name, value, ErrValue)
+	}
+
+	return p.exec(ctx, []byte(fmt.Sprintf("%s \"%s\"\r\n", name, value)))
+}
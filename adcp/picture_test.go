@@ -0,0 +1,82 @@
+package adcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestPicture(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	is.NoErr(proj.SetPower(ctx, true))
+	defer proj.SetPower(ctx, false)
+
+	is.NoErr(proj.SetPictureMode(ctx, "cinema"))
+	mode, err := proj.PictureMode(ctx)
+	is.NoErr(err)
+	is.Equal(mode, "cinema")
+
+	is.NoErr(proj.SetBrightness(ctx, 65))
+	brightness, err := proj.Brightness(ctx)
+	is.NoErr(err)
+	is.Equal(brightness, 65)
+
+	is.NoErr(proj.SetContrast(ctx, 0))
+	contrast, err := proj.Contrast(ctx)
+	is.NoErr(err)
+	is.Equal(contrast, 0)
+
+	is.NoErr(proj.SetSharpness(ctx, 100))
+	sharpness, err := proj.Sharpness(ctx)
+	is.NoErr(err)
+	is.Equal(sharpness, 100)
+
+	is.NoErr(proj.SetColorTemp(ctx, "low"))
+	temp, err := proj.ColorTemp(ctx)
+	is.NoErr(err)
+	is.Equal(temp, "low")
+
+	is.NoErr(proj.SetGammaCorrection(ctx, "2.4"))
+	gamma, err := proj.GammaCorrection(ctx)
+	is.NoErr(err)
+	is.Equal(gamma, "2.4")
+
+	// rejected before being sent
+	is.True(errors.Is(proj.SetBrightness(ctx, 101), ErrValue))
+	is.True(errors.Is(proj.SetHue(ctx, -1), ErrValue))
+	is.True(errors.Is(proj.SetPictureMode(ctx, ""), ErrValue))
+
+	// rejected by the projector
+	err = proj.SetPictureMode(ctx, "vivid")
+	is.True(errors.Is(err, ErrValue))
+
+	var cmdErr *CommandError
+	is.True(errors.As(err, &cmdErr))
+	is.Equal(cmdErr.Response, "err_val")
+
+	mode, err = proj.PictureMode(ctx)
+	is.NoErr(err)
+	is.Equal(mode, "cinema")
+}
+
+func TestPictureInactive(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	is.NoErr(proj.SetPower(ctx, false))
+
+	err := proj.SetBrightness(ctx, 40)
+	is.True(errors.Is(err, ErrInactive))
+
+	err = proj.SetColorTemp(ctx, "high")
+	is.True(errors.Is(err, ErrInactive))
+}
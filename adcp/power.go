@@ -3,6 +3,8 @@ package adcp
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 )
 
 var (
@@ -16,44 +18,192 @@ var (
 	PowerStandby = []byte("power \"off\"\r\n")
 )
 
+// PowerState is a state reported by power_status
+type PowerState string
+
+// Power states reported by power_status.
+const (
+	PowerStateStandby        PowerState = "standby"
+	PowerStateStartup        PowerState = "startup"
+	PowerStateOn             PowerState = "on"
+	PowerStateCooling1       PowerState = "cooling1"
+	PowerStateCooling2       PowerState = "cooling2"
+	PowerStateSavingCooling1 PowerState = "saving_cooling1"
+	PowerStateSavingCooling2 PowerState = "saving_cooling2"
+	PowerStateSavingStandby  PowerState = "saving_standby"
+)
+
+// On returns true if the projector is on or starting up
+func (s PowerState) On() bool {
+	return s == PowerStateOn || s == PowerStateStartup
+}
+
+// Transitioning returns true if the projector is starting up or cooling down.
+// Most commands are rejected with err_inactive while it is.
+func (s PowerState) Transitioning() bool {
+	switch s {
+	case PowerStateStartup, PowerStateCooling1, PowerStateCooling2, PowerStateSavingCooling1, PowerStateSavingCooling2:
+		return true
+	default:
+		return false
+	}
+}
+
+// how often the power state is checked while waiting for a transition to finish
+const _powerPollInterval = 250 * time.Millisecond
+
 // Power returns the status of the projector
 func (p *Projector) Power(ctx context.Context) (bool, error) {
-	state := false
+	state, err := p.PowerState(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return state.On(), nil
+}
+
+// PowerState returns the power state of the projector. It never waits for a transition to finish.
+func (p *Projector) PowerState(ctx context.Context) (PowerState, error) {
+	resp, err := p.sendCommand(ctx, p.Address, PowerStatus)
+	if err != nil {
+		return "", err
+	}
+
+	if err := checkResponse(PowerStatus, resp); err != nil {
+		return "", err
+	}
+
+	return parsePowerState(resp)
+}
 
-	resp, err := p.SendCommand(ctx, p.Address, PowerStatus)
+// parsePower parses the response to PowerStatus.
+func parsePower(resp string) (bool, error) {
+	state, err := parsePowerState(resp)
 	if err != nil {
 		return false, err
 	}
 
-	switch resp {
-	case `"startup"`:
-		state = true
-	case `"on"`:
-		state = true
-	case `"standby"`:
-	case `"cooling1"`:
-	case `"cooling2"`:
-	case `"saving_cooling1"`:
-	case `"saving_cooling2"`:
-	case `"saving_standby"`:
+	return state.On(), nil
+}
+
+// parsePowerState parses the response to PowerStatus.
+func parsePowerState(resp string) (PowerState, error) {
+	state := PowerState(strings.Trim(resp, "\""))
+
+	switch state {
+	case PowerStateStandby, PowerStateStartup, PowerStateOn, PowerStateCooling1, PowerStateCooling2,
+		PowerStateSavingCooling1, PowerStateSavingCooling2, PowerStateSavingStandby:
+		return state, nil
 	default:
-		return state, fmt.Errorf("unknown power state '%s'", resp)
+		return "", fmt.Errorf("unknown power state '%s'", resp)
 	}
-
-	return state, nil
 }
 
-// SetPower sets the status of the projector
+// SetPower sets the status of the projector. If WaitForPower is true, it waits until the projector
+// has finished starting up or cooling down, and commands sent in the meantime wait for it.
 func (p *Projector) SetPower(ctx context.Context, power bool) error {
 	cmd := PowerOn
 	if !power {
 		cmd = PowerStandby
 	}
 
-	resp, err := p.SendCommand(ctx, p.Address, cmd)
+	if !p.WaitForPower {
+		return p.exec(ctx, cmd)
+	}
+
+	done, err := p.beginTransition(ctx)
 	if err != nil {
 		return err
 	}
+	defer done()
 
-	return ResponseError(resp)
+	resp, err := p.sendWhenReady(ctx, p.Address, cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := checkOK(cmd, resp); err != nil {
+		return err
+	}
+
+	// wait for the projector to reach the steady state
+	state, err := p.waitForSteadyState(ctx)
+	switch {
+	case err != nil:
+		return err
+	case state.On() != power:
+		return fmt.Errorf("projector settled in %q", state)
+	}
+
+	return nil
+}
+
+// beginTransition marks the start of a power transition, which commands sent through
+// SendCommand wait for. It waits for any transition already in progress to finish first.
+// The returned function must be called when the transition is finished.
+func (p *Projector) beginTransition(ctx context.Context) (func(), error) {
+	for {
+		p.powerMu.Lock()
+		transition := p.transition
+		if transition == nil {
+			transition = make(chan struct{})
+			p.transition = transition
+			p.powerMu.Unlock()
+
+			return func() {
+				p.powerMu.Lock()
+				p.transition = nil
+				p.powerMu.Unlock()
+
+				close(transition)
+			}, nil
+		}
+		p.powerMu.Unlock()
+
+		select {
+		case <-transition:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("unable to wait for power transition: %w", ctx.Err())
+		}
+	}
+}
+
+// waitForTransition waits for a power transition started by SetPower to finish.
+func (p *Projector) waitForTransition(ctx context.Context) error {
+	p.powerMu.Lock()
+	transition := p.transition
+	p.powerMu.Unlock()
+
+	if transition == nil {
+		return nil
+	}
+
+	select {
+	case <-transition:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("unable to wait for power transition: %w", ctx.Err())
+	}
+}
+
+// waitForSteadyState polls the projector until it isn't starting up or cooling down.
+func (p *Projector) waitForSteadyState(ctx context.Context) (PowerState, error) {
+	ticker := time.NewTicker(_powerPollInterval)
+	defer ticker.Stop()
+
+	for {
+		state, err := p.PowerState(ctx)
+		switch {
+		case err != nil:
+			return "", err
+		case !state.Transitioning():
+			return state, nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return state, fmt.Errorf("unable to wait for projector to leave %q: %w", state, ctx.Err())
+		}
+	}
 }
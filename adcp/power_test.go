@@ -0,0 +1,134 @@
+package adcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/byuoitav/sony/adcp/adcptest"
+	"github.com/matryer/is"
+)
+
+// TestPower turns the projector on and then off, verifying that
+// it works after each step.
+func TestPower(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	is.NoErr(proj.SetPower(ctx, true))
+
+	pow, err := proj.Power(ctx)
+	is.NoErr(err)
+	is.True(pow)
+
+	is.NoErr(proj.SetPower(ctx, false))
+
+	pow, err = proj.Power(ctx)
+	is.NoErr(err)
+	is.True(!pow)
+}
+
+func TestPowerCooling(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sim.SetTransitionTimes(0, time.Minute)
+	defer sim.SetTransitionTimes(0, 0)
+	defer sim.SetState(func(s *adcptest.State) {
+		s.Power = adcptest.PowerStandby
+	})
+
+	is.NoErr(proj.SetPower(ctx, true))
+	is.NoErr(proj.SetPower(ctx, false))
+
+	pow, err := proj.Power(ctx)
+	is.NoErr(err)
+	is.True(!pow)
+	is.Equal(sim.State().Power, adcptest.PowerCooling1)
+
+	// the projector can't be turned on while it is cooling
+	is.True(proj.SetPower(ctx, true) != nil)
+}
+
+func TestPowerState(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sim.SetTransitionTimes(0, time.Minute)
+	defer sim.SetTransitionTimes(0, 0)
+	defer sim.SetState(func(s *adcptest.State) {
+		s.Power = adcptest.PowerStandby
+	})
+
+	is.NoErr(proj.SetPower(ctx, true))
+
+	state, err := proj.PowerState(ctx)
+	is.NoErr(err)
+	is.Equal(state, PowerStateOn)
+	is.True(state.On())
+	is.True(!state.Transitioning())
+
+	is.NoErr(proj.SetPower(ctx, false))
+
+	state, err = proj.PowerState(ctx)
+	is.NoErr(err)
+	is.Equal(state, PowerStateCooling1)
+	is.True(!state.On())
+	is.True(state.Transitioning())
+}
+
+func TestWaitForPower(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s := adcptest.NewServer()
+	defer s.Close()
+
+	s.SetTransitionTimes(300*time.Millisecond, 300*time.Millisecond)
+
+	p := &Projector{
+		Address:      s.Host(),
+		Port:         s.Port(),
+		WaitForPower: true,
+	}
+
+	start := time.Now()
+	is.NoErr(p.SetPower(ctx, true))
+	is.True(time.Since(start) >= 300*time.Millisecond)
+	is.Equal(s.State().Power, adcptest.PowerOn)
+
+	// turning on while cooling waits for the projector to finish cooling
+	is.NoErr(p.SetPower(ctx, false))
+	is.Equal(s.State().Power, adcptest.PowerStandby)
+
+	done := make(chan error)
+	go func() {
+		done <- p.SetPower(ctx, true)
+	}()
+
+	// commands sent while the projector is starting up wait for it
+	time.Sleep(50 * time.Millisecond)
+	is.NoErr(p.SetAudioVideoInput(ctx, "", "hdmi2"))
+	is.NoErr(<-done)
+	is.Equal(s.State().Input, "hdmi2")
+
+	// commands rejected because of a transition started elsewhere are resent once it finishes
+	other := &Projector{
+		Address: s.Host(),
+		Port:    s.Port(),
+	}
+
+	is.NoErr(other.SetPower(ctx, false))
+	is.Equal(s.State().Power, adcptest.PowerCooling1)
+
+	is.NoErr(p.SetPower(ctx, true))
+	is.Equal(s.State().Power, adcptest.PowerOn)
+}
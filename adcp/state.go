@@ -0,0 +1,149 @@
+package adcp
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/byuoitav/pooled"
+)
+
+// State is a snapshot of the projector's state, from State.
+type State struct {
+	Power   bool
+	Blanked bool
+
+	// Input is the projector's current input. It is empty if the projector is off.
+	Input string
+
+	// Volumes and Mutes are keyed by "", like Volumes and Mutes, and are empty if the projector is off.
+	Volumes map[string]int
+	Mutes   map[string]bool
+
+	// Errors holds the error for each field that couldn't be read,
+	// keyed by "blank", "input", "volume", or "mute".
+	Errors map[string]error
+}
+
+// State returns the power, blank, input, volume, and mute of the projector using a single connection.
+// It returns an error if the projector can't be reached or its power can't be read; errors reading
+// any other field are in State.Errors. If WaitForPower is true, it waits for a power transition to finish first.
+func (p *Projector) State(ctx context.Context) (State, error) {
+	state := State{
+		Volumes: map[string]int{},
+		Mutes:   map[string]bool{},
+		Errors:  map[string]error{},
+	}
+
+	if p.WaitForPower {
+		if err := p.waitForTransition(ctx); err != nil {
+			return State{}, err
+		}
+	}
+
+	err := p.do(ctx, p.Address, func(conn pooled.Conn) error {
+		resp, err := p.command(conn, PowerStatus)
+		if err != nil {
+			return err
+		}
+
+		if err := checkResponse(PowerStatus, resp); err != nil {
+			return err
+		}
+
+		if state.Power, err = parsePower(resp); err != nil {
+			return err
+		}
+
+		// the rest of the commands fail with err_inactive while the projector is off
+		if !state.Power {
+			return nil
+		}
+
+		get := func(field string, cmd []byte, parse func(string) error) error {
+			if err := p.pause(ctx, conn); err != nil {
+				return err
+			}
+
+			resp, err := p.command(conn, cmd)
+			if err != nil {
+				return err
+			}
+
+			if err := checkResponse(cmd, resp); err != nil {
+				state.Errors[field] = err
+				return nil
+			}
+
+			if err := parse(resp); err != nil {
+				state.Errors[field] = err
+			}
+
+			return nil
+		}
+
+		fields := []struct {
+			name  string
+			cmd   []byte
+			parse func(string) error
+		}{
+			{"blank", BlankStatus, func(resp string) (err error) {
+				state.Blanked, err = parseOnOff("blanked", resp)
+				return err
+			}},
+			{"input", InputStatus, func(resp string) error {
+				state.Input = strings.Trim(resp, "\"")
+				return nil
+			}},
+			{"volume", volumeStatus, func(resp string) error {
+				volume, err := strconv.Atoi(resp)
+				if err != nil {
+					return err
+				}
+
+				state.Volumes[""] = adcpToNormalVolume(volume)
+				return nil
+			}},
+			{"mute", muteStatus, func(resp string) error {
+				muted, err := parseOnOff("muted", resp)
+				if err != nil {
+					return err
+				}
+
+				state.Mutes[""] = muted
+				return nil
+			}},
+		}
+
+		for _, field := range fields {
+			// errors from the connection stop the snapshot; errors from the projector don't
+			if err := get(field.name, field.cmd, field.parse); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return State{}, err
+	}
+
+	return state, nil
+}
+
+// pause waits for CommandDelay and empties conn's read buffer before another command is sent
+// on conn, like do and the pool do between commands.
+func (p *Projector) pause(ctx context.Context, conn pooled.Conn) error {
+	timer := time.NewTimer(p.commandDelay())
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	_, err := conn.EmptyReadBuffer(orDefault(p.ReadTimeout, DefaultIOTimeout))
+	return err
+}
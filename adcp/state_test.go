@@ -0,0 +1,82 @@
+package adcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestState(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	is.NoErr(proj.SetPower(ctx, false))
+
+	state, err := proj.State(ctx)
+	is.NoErr(err)
+	is.True(!state.Power)
+	is.Equal(len(state.Volumes), 0)
+	is.Equal(len(state.Errors), 0)
+
+	is.NoErr(proj.SetPower(ctx, true))
+	defer proj.SetPower(ctx, false)
+
+	is.NoErr(proj.SetVolume(ctx, "", 40))
+	is.NoErr(proj.SetMute(ctx, "", true))
+	defer proj.SetMute(ctx, "", false)
+
+	sim.SetError("input", "err_internal1")
+	defer sim.ClearError("input")
+
+	state, err = proj.State(ctx)
+	is.NoErr(err)
+	is.True(state.Power)
+	is.Equal(state.Volumes[""], 40)
+	is.True(state.Mutes[""])
+	is.Equal(state.Input, "")
+	is.True(errors.Is(state.Errors["input"], ErrInternal1))
+	is.Equal(len(state.Errors), 1)
+}
+
+func TestStateCommandDelay(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	p := &Projector{
+		Address:      sim.Host(),
+		Port:         sim.Port(),
+		CommandDelay: 100 * time.Millisecond,
+	}
+
+	is.NoErr(p.SetPower(ctx, true))
+	defer p.SetPower(ctx, false)
+
+	// power, then the four other fields
+	start := time.Now()
+	_, err := p.State(ctx)
+	is.NoErr(err)
+	is.True(time.Since(start) >= 4*p.CommandDelay)
+}
+
+func TestStatePowerError(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sim.SetError("power_status", "err_internal1")
+	defer sim.ClearError("power_status")
+
+	_, err := proj.State(ctx)
+
+	var cErr *CommandError
+	is.True(errors.As(err, &cErr))
+	is.True(errors.Is(err, ErrInternal1))
+}
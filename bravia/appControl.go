@@ -0,0 +1,86 @@
+package bravia
+
+import (
+	"context"
+	"net/url"
+)
+
+// App is an application installed on the display.
+type App struct {
+	Title string `json:"title"`
+	URI   string `json:"uri"`
+	Icon  string `json:"icon,omitempty"`
+}
+
+type appStatus struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// Applications returns the applications installed on the display.
+func (d *Display) Applications(ctx context.Context) ([]App, error) {
+	req := request{
+		Version: "1.0",
+		Method:  "getApplicationList",
+		Params:  []map[string]interface{}{},
+	}
+
+	var apps []App
+	if err := d.doRequest(ctx, "appControl", req, &apps); err != nil {
+		return nil, err
+	}
+
+	return apps, nil
+}
+
+// LaunchApp opens the application with the given uri, as returned by Applications.
+func (d *Display) LaunchApp(ctx context.Context, uri string) error {
+	req := request{
+		Version: "1.0",
+		Method:  "setActiveApp",
+		Params: []map[string]interface{}{
+			{
+				"uri": uri,
+			},
+		},
+	}
+
+	return d.doRequest(ctx, "appControl", req)
+}
+
+// OpenURL opens rawURL in the display's built-in browser.
+func (d *Display) OpenURL(ctx context.Context, rawURL string) error {
+	return d.LaunchApp(ctx, "localapp://webappruntime?url="+url.QueryEscape(rawURL))
+}
+
+// TerminateApps closes every application that is open on the display.
+func (d *Display) TerminateApps(ctx context.Context) error {
+	req := request{
+		Version: "1.0",
+		Method:  "terminateApps",
+		Params:  []map[string]interface{}{},
+	}
+
+	return d.doRequest(ctx, "appControl", req)
+}
+
+// AppStatus returns whether each of the display's built-in functions (ie, "webBrowse", "textInput", or "cursorDisplay") is in use.
+func (d *Display) AppStatus(ctx context.Context) (map[string]bool, error) {
+	req := request{
+		Version: "1.0",
+		Method:  "getApplicationStatusList",
+		Params:  []map[string]interface{}{},
+	}
+
+	var list []appStatus
+	if err := d.doRequest(ctx, "appControl", req, &list); err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]bool, len(list))
+	for _, status := range list {
+		statuses[status.Name] = status.Status == "on"
+	}
+
+	return statuses, nil
+}
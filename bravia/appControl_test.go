@@ -0,0 +1,63 @@
+package bravia
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestApplications(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	is.NoErr(disp.SetPower(ctx, true))
+
+	apps, err := disp.Applications(ctx)
+	is.NoErr(err)
+	is.True(len(apps) > 0)
+
+	for _, app := range apps {
+		t.Logf("%+v", app)
+	}
+
+	is.NoErr(disp.LaunchApp(ctx, apps[0].URI))
+	if srv != nil {
+		is.Equal(srv.State().ActiveApp, apps[0].URI)
+	}
+
+	is.NoErr(disp.TerminateApps(ctx))
+	is.NoErr(disp.SetPower(ctx, false))
+}
+
+func TestOpenURL(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	is.NoErr(disp.SetPower(ctx, true))
+	is.NoErr(disp.OpenURL(ctx, "https://www.byu.edu/?a=b&c=d"))
+
+	if srv != nil {
+		is.Equal(srv.State().ActiveApp, "localapp://webappruntime?url=https%3A%2F%2Fwww.byu.edu%2F%3Fa%3Db%26c%3Dd")
+	}
+
+	status, err := disp.AppStatus(ctx)
+	is.NoErr(err)
+	is.True(status["webBrowse"])
+
+	is.NoErr(disp.TerminateApps(ctx))
+
+	status, err = disp.AppStatus(ctx)
+	is.NoErr(err)
+	is.True(!status["webBrowse"])
+
+	is.NoErr(disp.SetPower(ctx, false))
+}
@@ -4,24 +4,31 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"time"
+
+	"go.uber.org/zap"
 )
 
+// Volumes returns the volume of each block. If no blocks are given, the volume of every
+// target in use for the display's current audio output is returned.
 func (d *Display) Volumes(ctx context.Context, blocks []string) (map[string]int, error) {
 	infos, err := d.getVolumeInformation(ctx)
 	if err != nil {
-		var bErr *Error
-		if errors.As(err, &bErr) {
-			switch bErr.code {
-			case _displayOff:
-				return nil, nil
-			}
+		if errors.Is(err, ErrDisplayOff) {
+			return nil, nil
 		}
 
 		return nil, err
 	}
 
+	if len(blocks) == 0 {
+		if blocks, err = d.activeTargets(ctx, infos); err != nil {
+			return nil, err
+		}
+	}
+
 	vols := make(map[string]int, len(blocks))
 
 	for _, block := range blocks {
@@ -29,7 +36,7 @@ func (d *Display) Volumes(ctx context.Context, blocks []string) (map[string]int,
 		for _, info := range infos {
 			if block == info.Target {
 				found = true
-				vols[block] = info.Volume
+				vols[block] = d.normalVolume(info, info.Volume)
 				break
 			}
 		}
@@ -42,37 +49,163 @@ func (d *Display) Volumes(ctx context.Context, blocks []string) (map[string]int,
 	return vols, nil
 }
 
+// SetVolume sets the volume of block. vol is from 0-100 if NormalizeVolume is true, otherwise it is in the display's own levels.
 func (d *Display) SetVolume(ctx context.Context, block string, vol int) error {
+	version, err := d.negotiate(ctx, "audio", "setAudioVolume", "1.2", "1.0")
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case d.NormalizeVolume:
+		info, err := d.volumeInformation(ctx, block)
+		if err != nil {
+			return err
+		}
+
+		vol = d.deviceVolume(info, vol)
+	case d.MaxVolume > 0 && vol > d.MaxVolume:
+		vol = d.MaxVolume
+	}
+
+	return d.setAudioVolume(ctx, version, block, strconv.Itoa(vol))
+}
+
+// StepVolume changes the volume of block by delta, using the display's relative volume form so that
+// steps from several controllers at once don't race. delta is scaled from 0-100 if NormalizeVolume is true.
+func (d *Display) StepVolume(ctx context.Context, block string, delta int) error {
+	if delta == 0 {
+		return nil
+	}
+
+	version, err := d.negotiate(ctx, "audio", "setAudioVolume", "1.2", "1.0")
+	if err != nil {
+		return err
+	}
+
+	if d.NormalizeVolume || d.MaxVolume > 0 {
+		info, err := d.volumeInformation(ctx, block)
+		if err != nil {
+			return err
+		}
+
+		if d.NormalizeVolume {
+			delta = d.deviceDelta(info, delta)
+		}
+
+		// the display only clamps to its own maximum, so keep steps under the ceiling
+		if d.MaxVolume > 0 && delta > 0 && info.Volume+delta > d.MaxVolume {
+			delta = d.MaxVolume - info.Volume
+			if delta <= 0 {
+				return nil
+			}
+		}
+	}
+
+	return d.setAudioVolume(ctx, version, block, fmt.Sprintf("%+d", delta))
+}
+
+// RampVolume fades the volume of block to vol over duration, in as many steps as RequestDelay allows.
+// vol is from 0-100 if NormalizeVolume is true, otherwise it is in the display's own levels.
+func (d *Display) RampVolume(ctx context.Context, block string, vol int, duration time.Duration) error {
+	vols, err := d.Volumes(ctx, []string{block})
+	if err != nil {
+		return err
+	}
+
+	start, ok := vols[block]
+	if !ok {
+		return fmt.Errorf("unable to get volume: %w", ErrDisplayOff)
+	}
+
+	steps := vol - start
+	if steps < 0 {
+		steps = -steps
+	}
+
+	if steps == 0 {
+		return nil
+	}
+
+	// each step is a request, plus one to get the range if volumes are normalized
+	minInterval := d.RequestDelay
+	if d.NormalizeVolume {
+		minInterval *= 2
+	}
+
+	if minInterval > 0 && duration/time.Duration(steps) < minInterval {
+		steps = int(duration / minInterval)
+	}
+
+	if steps < 1 {
+		steps = 1
+	}
+
+	interval := duration / time.Duration(steps)
+	if interval <= 0 {
+		return d.SetVolume(ctx, block, vol)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for i := 1; i <= steps; i++ {
+		if err := d.SetVolume(ctx, block, start+(vol-start)*i/steps); err != nil {
+			return err
+		}
+
+		if i == steps {
+			break
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("unable to ramp volume: %w", ctx.Err())
+		}
+	}
+
+	return nil
+}
+
+func (d *Display) setAudioVolume(ctx context.Context, version, block, volume string) error {
+	params := map[string]interface{}{
+		"target": block,
+		"volume": volume,
+	}
+
+	// 1.0 always shows the volume bar; 1.2 lets us hide it
+	if version == "1.2" {
+		params["ui"] = "off"
+	}
+
 	req := request{
-		Version: "1.2",
+		Version: version,
 		Method:  "setAudioVolume",
-		Params: []map[string]interface{}{
-			{
-				"target": block,
-				"volume": strconv.Itoa(vol),
-				"ui":     "off",
-			},
-		},
+		Params:  []map[string]interface{}{params},
 	}
 
-	_, err := d.doRequest(ctx, "audio", req)
-	return err
+	return d.doRequest(ctx, "audio", req)
 }
 
+// Mutes returns whether each block is muted. If no blocks are given, the mute of every
+// target in use for the display's current audio output is returned.
 func (d *Display) Mutes(ctx context.Context, blocks []string) (map[string]bool, error) {
 	infos, err := d.getVolumeInformation(ctx)
 	if err != nil {
-		var bErr *Error
-		if errors.As(err, &bErr) {
-			switch bErr.code {
-			case _displayOff:
-				return nil, nil
-			}
+		if errors.Is(err, ErrDisplayOff) {
+			return nil, nil
 		}
 
 		return nil, err
 	}
 
+	if len(blocks) == 0 {
+		if blocks, err = d.activeTargets(ctx, infos); err != nil {
+			return nil, err
+		}
+	}
+
 	mutes := make(map[string]bool, len(blocks))
 
 	for _, block := range blocks {
@@ -106,29 +239,19 @@ func (d *Display) SetMute(ctx context.Context, block string, mute bool) error {
 		},
 	}
 
-	_, err := d.doRequest(ctx, "audio", req)
-	if err != nil {
+	if err := d.doRequest(ctx, "audio", req); err != nil {
 		return err
 	}
 
 	// wait for display to mute
-	ticker := time.NewTicker(500 * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			mutes, err := d.Mutes(ctx, []string{block})
-			switch {
-			case err != nil:
-				return fmt.Errorf("unable to confirm mute set: %w", err)
-			case mutes[block] == mute:
-				return nil
-			}
-		case <-ctx.Done():
-			return fmt.Errorf("unable to confirm mute set: %w", ctx.Err())
+	return d.confirm(ctx, "mute", mute, func(ctx context.Context) (interface{}, error) {
+		mutes, err := d.Mutes(ctx, []string{block})
+		if err != nil {
+			return nil, err
 		}
-	}
+
+		return mutes[block], nil
+	})
 }
 
 type volumeInformation struct {
@@ -146,58 +269,125 @@ func (d *Display) getVolumeInformation(ctx context.Context) ([]volumeInformation
 		Params:  []map[string]interface{}{},
 	}
 
-	res, err := d.doRequest(ctx, "audio", req)
-	switch {
-	case err != nil:
+	var infos []volumeInformation
+	if err := d.doRequest(ctx, "audio", req, &infos); err != nil {
 		return nil, err
-	case len(res) < 1:
-		return nil, fmt.Errorf("unexpected response: %+v", res)
 	}
 
-	list, ok := res[0].([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("unexpected response: %+v", res)
+	// remember each target's range, to normalize volume notifications with
+	d.volMu.Lock()
+	d.volRanges = make(map[string]volumeInformation, len(infos))
+	for _, info := range infos {
+		d.volRanges[info.Target] = info
 	}
+	d.volMu.Unlock()
 
-	var infos []volumeInformation
-	for _, item := range list {
-		m, ok := item.(map[string]interface{})
-		if !ok {
-			return nil, fmt.Errorf("unexpected response: %+v", res)
-		}
+	return infos, nil
+}
 
-		var info volumeInformation
+// volumeInformation returns the volume information of block.
+func (d *Display) volumeInformation(ctx context.Context, block string) (volumeInformation, error) {
+	infos, err := d.getVolumeInformation(ctx)
+	if err != nil {
+		return volumeInformation{}, err
+	}
 
-		info.Target, ok = m["target"].(string)
-		if !ok {
-			return nil, fmt.Errorf("unexpected response: %+v", res)
+	for _, info := range infos {
+		if info.Target == block {
+			return info, nil
 		}
+	}
 
-		vol, ok := m["volume"].(float64)
-		if !ok {
-			return nil, fmt.Errorf("unexpected response: %+v", res)
-		}
-		info.Volume = int(vol)
+	return volumeInformation{}, fmt.Errorf("block %q not present", block)
+}
 
-		info.Mute, ok = m["mute"].(bool)
-		if !ok {
-			return nil, fmt.Errorf("unexpected response: %+v", res)
+// volumeRange returns the range of levels that 0-100 is scaled into for info.
+func (d *Display) volumeRange(info volumeInformation) (int, int) {
+	min, max := info.MinVolume, info.MaxVolume
+	if d.MaxVolume > 0 && d.MaxVolume < max {
+		max = d.MaxVolume
+	}
+
+	return min, max
+}
+
+// normalVolume scales level from the display's levels for info into 0-100, if NormalizeVolume is true.
+func (d *Display) normalVolume(info volumeInformation, level int) int {
+	if !d.NormalizeVolume {
+		return level
+	}
+
+	min, max := d.volumeRange(info)
+	switch {
+	case max <= min:
+		return level
+	case level <= min:
+		return 0
+	case level >= max:
+		return 100
+	default:
+		return int(math.Round(float64(level-min) * 100 / float64(max-min)))
+	}
+}
+
+// deviceVolume scales level from 0-100 into the display's levels for info.
+func (d *Display) deviceVolume(info volumeInformation, level int) int {
+	min, max := d.volumeRange(info)
+	switch {
+	case max <= min:
+		return level
+	case level <= 0:
+		return min
+	case level >= 100:
+		return max
+	default:
+		return min + int(math.Round(float64(level)*float64(max-min)/100))
+	}
+}
+
+// deviceDelta scales a change in volume from 0-100 into the display's levels for info.
+// A non-zero delta always changes the volume by at least one level.
+func (d *Display) deviceDelta(info volumeInformation, delta int) int {
+	min, max := d.volumeRange(info)
+	if max <= min {
+		return delta
+	}
+
+	scaled := int(math.Round(float64(delta) * float64(max-min) / 100))
+	switch {
+	case scaled == 0 && delta > 0:
+		return 1
+	case scaled == 0 && delta < 0:
+		return -1
+	default:
+		return scaled
+	}
+}
+
+// normalizeNotified scales level for target into 0-100 using the range from the last getVolumeInformation,
+// asking the display for the ranges if target's isn't known yet. It returns false if the range still isn't known.
+func (d *Display) normalizeNotified(ctx context.Context, target string, level int) (int, bool) {
+	d.volMu.Lock()
+	info, ok := d.volRanges[target]
+	d.volMu.Unlock()
+
+	if !ok {
+		infos, err := d.getVolumeInformation(ctx)
+		if err != nil {
+			d.Log.Warn("Unable to get volume ranges", zap.Error(err))
+			return 0, false
 		}
 
-		vol, ok = m["maxVolume"].(float64)
-		if !ok {
-			return nil, fmt.Errorf("unexpected response: %+v", res)
+		for _, i := range infos {
+			if i.Target == target {
+				info, ok = i, true
+			}
 		}
-		info.MaxVolume = int(vol)
 
-		vol, ok = m["minVolume"].(float64)
 		if !ok {
-			return nil, fmt.Errorf("unexpected response: %+v", res)
+			return 0, false
 		}
-		info.MinVolume = int(vol)
-
-		infos = append(infos, info)
 	}
 
-	return infos, nil
+	return d.normalVolume(info, level), true
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/byuoitav/sony/bravia/braviatest"
 	"github.com/matryer/is"
 	"go.uber.org/zap/zaptest"
 )
@@ -53,6 +54,52 @@ func TestVolume(t *testing.T) {
 	is.NoErr(disp.SetPower(ctx, false))
 }
 
+func TestStepVolume(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	is.NoErr(disp.SetPower(ctx, true))
+
+	test := func(delta, expected int) {
+		is.NoErr(disp.StepVolume(ctx, "speaker", delta))
+
+		vols, err := disp.Volumes(ctx, []string{"speaker"})
+		is.NoErr(err)
+		is.Equal(vols["speaker"], expected)
+	}
+
+	is.NoErr(disp.SetVolume(ctx, "speaker", 20))
+	test(5, 25)
+	test(-10, 15)
+	test(0, 15)
+
+	is.NoErr(disp.SetPower(ctx, false))
+}
+
+func TestRampVolume(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	is.NoErr(disp.SetPower(ctx, true))
+	is.NoErr(disp.SetVolume(ctx, "speaker", 10))
+
+	start := time.Now()
+	is.NoErr(disp.RampVolume(ctx, "speaker", 30, 500*time.Millisecond))
+	is.True(time.Since(start) >= 400*time.Millisecond)
+
+	vols, err := disp.Volumes(ctx, []string{"speaker"})
+	is.NoErr(err)
+	is.Equal(vols["speaker"], 30)
+
+	is.NoErr(disp.SetPower(ctx, false))
+}
+
 func TestMute(t *testing.T) {
 	is := is.New(t)
 	disp.Log = zaptest.NewLogger(t)
@@ -106,3 +153,71 @@ func TestOffMutes(t *testing.T) {
 	is.NoErr(err)
 	is.True(len(mutes) == 0)
 }
+
+func TestNormalizeVolume(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv := braviatest.NewServer("")
+	defer srv.Close()
+
+	srv.SetState(func(s *braviatest.State) {
+		s.Power = true
+		s.Volumes = []braviatest.Volume{
+			{Target: "speaker", Volume: 10, MinVolume: 0, MaxVolume: 50},
+		}
+	})
+
+	d := &Display{
+		Address:         srv.Address(),
+		Log:             zaptest.NewLogger(t),
+		RequestDelay:    10 * time.Millisecond,
+		NormalizeVolume: true,
+	}
+
+	vols, err := d.Volumes(ctx, []string{"speaker"})
+	is.NoErr(err)
+	is.Equal(vols["speaker"], 20)
+
+	is.NoErr(d.SetVolume(ctx, "speaker", 50))
+	is.Equal(srv.State().Volumes[0].Volume, 25)
+
+	vols, err = d.Volumes(ctx, []string{"speaker"})
+	is.NoErr(err)
+	is.Equal(vols["speaker"], 50)
+
+	// 100 is scaled to the ceiling instead of the maximum
+	d.MaxVolume = 40
+	is.NoErr(d.SetVolume(ctx, "speaker", 100))
+	is.Equal(srv.State().Volumes[0].Volume, 40)
+
+	vols, err = d.Volumes(ctx, []string{"speaker"})
+	is.NoErr(err)
+	is.Equal(vols["speaker"], 100)
+}
+
+func TestVolumeCeiling(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv := braviatest.NewServer("")
+	defer srv.Close()
+
+	srv.SetState(func(s *braviatest.State) {
+		s.Power = true
+	})
+
+	d := &Display{
+		Address:      srv.Address(),
+		Log:          zaptest.NewLogger(t),
+		RequestDelay: 10 * time.Millisecond,
+		MaxVolume:    30,
+	}
+
+	is.NoErr(d.SetVolume(ctx, "speaker", 80))
+	is.Equal(srv.State().Volumes[0].Volume, 30)
+}
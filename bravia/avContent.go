@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"strings"
-	"time"
 )
 
 func (d *Display) AudioVideoInputs(ctx context.Context) (map[string]string, error) {
@@ -15,34 +14,17 @@ func (d *Display) AudioVideoInputs(ctx context.Context) (map[string]string, erro
 		Params:  []map[string]interface{}{},
 	}
 
-	res, err := d.doRequest(ctx, "avContent", req)
-	switch {
-	case err != nil:
-		var bErr *Error
-		if errors.As(err, &bErr) {
-			switch bErr.code {
-			case _displayOff:
-				return nil, nil
-			}
+	var info playingContentInfo
+	if err := d.doRequest(ctx, "avContent", req, &info); err != nil {
+		if errors.Is(err, ErrDisplayOff) {
+			return nil, nil
 		}
 
 		return nil, err
-	case len(res) < 1:
-		return nil, fmt.Errorf("unexpected response: %+v", res)
-	}
-
-	m, ok := res[0].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("unexpected response: %+v", res)
-	}
-
-	str, ok := m["uri"].(string)
-	if !ok {
-		return nil, fmt.Errorf("unexpected response: %+v", res)
 	}
 
 	return map[string]string{
-		"": strings.TrimPrefix(str, "extInput:"),
+		"": strings.TrimPrefix(info.URI, "extInput:"),
 	}, nil
 }
 
@@ -58,143 +40,127 @@ func (d *Display) SetAudioVideoInput(ctx context.Context, _, input string) error
 		},
 	}
 
-	_, err := d.doRequest(ctx, "avContent", req)
-	if err != nil {
+	if err := d.doRequest(ctx, "avContent", req); err != nil {
 		return err
 	}
 
 	// wait for input to change
-	ticker := time.NewTicker(500 * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			inputs, err := d.AudioVideoInputs(ctx)
-			switch {
-			case err != nil:
-				return fmt.Errorf("unable to confirm input set: %w", err)
-			case inputs[""] == input:
-				return nil
-			}
-		case <-ctx.Done():
-			return fmt.Errorf("unable to confirm power set: %w", ctx.Err())
+	return d.confirm(ctx, "input", input, func(ctx context.Context) (interface{}, error) {
+		inputs, err := d.AudioVideoInputs(ctx)
+		if err != nil {
+			return nil, err
 		}
-	}
+
+		return inputs[""], nil
+	})
 }
 
-type inputStatus struct {
-	URI        string `json:"uri"`
-	Title      string `json:"title"`
-	Connection bool   `json:"connection"`
-	Label      string `json:"label"`
-	Icon       string `json:"icon"`
-	Status     string `json:"status"`
+type playingContentInfo struct {
+	URI    string `json:"uri"`
+	Source string `json:"source,omitempty"`
+	Title  string `json:"title,omitempty"`
 }
 
-func (d *Display) getCurrentExternalInputsStatus(ctx context.Context) ([]inputStatus, error) {
-	req := request{
-		Version: "1.1",
-		Method:  "getCurrentExternalInputsStatus",
-		Params:  []map[string]interface{}{},
-	}
+// Input is an external input on the display (ie, an hdmi port).
+type Input struct {
+	// URI is the full uri of the input, ie "extInput:hdmi?port=1".
+	URI string `json:"uri"`
 
-	res, err := d.doRequest(ctx, "avContent", req)
-	switch {
-	case err != nil:
+	// Input is the input in the format used by AudioVideoInputs and SetAudioVideoInput, ie "hdmi?port=1".
+	Input string `json:"-"`
+
+	// Title is the name of the input, ie "HDMI 1".
+	Title string `json:"title"`
+
+	// Label is the label the user has given the input on the display, if any.
+	Label string `json:"label"`
+
+	// Icon is the type of device connected to the input, ie "meta:hdmi" or "meta:game".
+	Icon string `json:"icon"`
+
+	// Connection is true if a device is connected to the input.
+	Connection bool `json:"connection"`
+
+	// Status is "true" if the input has a signal, "false" if it doesn't, or "" if the display can't tell.
+	// It is only reported by displays that support getCurrentExternalInputsStatus 1.1.
+	Status string `json:"status,omitempty"`
+}
+
+// Inputs returns every external input on the display, with its label and connection state.
+func (d *Display) Inputs(ctx context.Context) ([]Input, error) {
+	inputs, err := d.getCurrentExternalInputsStatus(ctx)
+	if err != nil {
 		return nil, err
-	case len(res) < 1:
-		return nil, fmt.Errorf("unexpected response: %+v", res)
 	}
 
-	list, ok := res[0].([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("unexpected response: %+v", res)
+	for i := range inputs {
+		inputs[i].Input = strings.TrimPrefix(inputs[i].URI, "extInput:")
 	}
 
-	var statuses []inputStatus
-	for _, item := range list {
-		m, ok := item.(map[string]interface{})
-		if !ok {
-			return nil, fmt.Errorf("unexpected response: %+v", res)
-		}
-
-		var status inputStatus
+	return inputs, nil
+}
 
-		status.URI, ok = m["uri"].(string)
-		if !ok {
-			return nil, fmt.Errorf("unexpected response: %+v", res)
-		}
+// ActiveSignal returns true if the given input (ie, "hdmi?port=1") has a signal. If port is empty,
+// the display's current input is checked. A display that is off never has an active signal.
+func (d *Display) ActiveSignal(ctx context.Context, port string) (bool, error) {
+	// displays keep reporting the inputs' signals while they're off
+	power, err := d.Power(ctx)
+	switch {
+	case err != nil:
+		return false, err
+	case !power:
+		return false, nil
+	}
 
-		status.Title, ok = m["title"].(string)
-		if !ok {
-			return nil, fmt.Errorf("unexpected response: %+v", res)
+	if port == "" {
+		cur, err := d.AudioVideoInputs(ctx)
+		switch {
+		case err != nil:
+			return false, err
+		case cur == nil:
+			return false, nil
 		}
 
-		status.Connection, ok = m["connection"].(bool)
-		if !ok {
-			return nil, fmt.Errorf("unexpected response: %+v", res)
-		}
+		port = cur[""]
+	}
 
-		status.Label, ok = m["label"].(string)
-		if !ok {
-			return nil, fmt.Errorf("unexpected response: %+v", res)
-		}
+	inputs, err := d.Inputs(ctx)
+	if err != nil {
+		return false, err
+	}
 
-		status.Icon, ok = m["icon"].(string)
-		if !ok {
-			return nil, fmt.Errorf("unexpected response: %+v", res)
+	for _, input := range inputs {
+		if input.Input != port {
+			continue
 		}
 
-		status.Status, ok = m["status"].(string)
-		if !ok {
-			return nil, fmt.Errorf("unexpected response: %+v", res)
+		// displays that only support 1.0 don't report the signal, so the best we can do is the connection
+		if input.Status == "" {
+			return input.Connection, nil
 		}
 
-		statuses = append(statuses, status)
+		return input.Status == "true", nil
 	}
 
-	return statuses, nil
+	return false, fmt.Errorf("input %q not present", port)
 }
 
-/*
-// GetActiveSignal determines if the current input on the TV is active or not
-func GetActiveSignal(address, port string) (structs.ActiveSignal, *nerr.E) {
-	var output structs.ActiveSignal
-
-	payload := SonyTVRequest{
-		Params:  []map[string]interface{}{},
-		Method:  "getCurrentExternalInputsStatus",
-		ID:      1,
-		Version: "1.1",
-	}
-
-	response, err := PostHTTP(address, payload, "avContent")
+func (d *Display) getCurrentExternalInputsStatus(ctx context.Context) ([]Input, error) {
+	version, err := d.negotiate(ctx, "avContent", "getCurrentExternalInputsStatus", "1.1", "1.0")
 	if err != nil {
-		return output, nerr.Translate(err)
+		return nil, err
 	}
 
-	var outputStruct SonyMultiAVContentResponse
-	err = json.Unmarshal(response, &outputStruct)
-	if err != nil || len(outputStruct.Result) < 1 {
-		return output, nerr.Translate(err)
+	req := request{
+		Version: version,
+		Method:  "getCurrentExternalInputsStatus",
+		Params:  []map[string]interface{}{},
 	}
-	//we need to parse the response for the value
 
-	log.L.Debugf("%+v", outputStruct)
-
-	regexStr := `extInput:(.*?)\?port=(.*)`
-	re := regexp.MustCompile(regexStr)
-
-	for _, result := range outputStruct.Result[0] {
-		if result.Status == "true" {
-			matches := re.FindStringSubmatch(result.URI)
-			tempActive := fmt.Sprintf("%v!%v", matches[1], matches[2])
-
-			output.Active = (tempActive == port)
-		}
+	var statuses []Input
+	if err := d.doRequest(ctx, "avContent", req, &statuses); err != nil {
+		return nil, err
 	}
 
-	return output, nil
+	return statuses, nil
 }
-*/
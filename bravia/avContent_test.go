@@ -2,6 +2,7 @@ package bravia
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -70,3 +71,63 @@ func TestOffInputs(t *testing.T) {
 	is.NoErr(err)
 	is.True(len(inputs) == 0)
 }
+
+func TestInputs(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	inputs, err := disp.Inputs(ctx)
+	is.NoErr(err)
+	is.True(len(inputs) > 0)
+
+	for _, input := range inputs {
+		t.Logf("%+v", input)
+		is.True(strings.HasPrefix(input.URI, "extInput:"))
+		is.True(!strings.HasPrefix(input.Input, "extInput:"))
+	}
+
+	if srv != nil {
+		is.Equal(inputs[0].Input, "hdmi?port=1")
+		is.True(inputs[0].Connection)
+		is.True(!inputs[2].Connection)
+	}
+}
+
+func TestActiveSignal(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	is.NoErr(disp.SetPower(ctx, true))
+	is.NoErr(disp.SetAudioVideoInput(ctx, "", "hdmi?port=1"))
+
+	active, err := disp.ActiveSignal(ctx, "")
+	is.NoErr(err)
+	t.Logf("hdmi?port=1 active: %v", active)
+
+	if srv != nil {
+		is.True(active)
+
+		active, err = disp.ActiveSignal(ctx, "hdmi?port=3")
+		is.NoErr(err)
+		is.True(!active)
+	}
+
+	_, err = disp.ActiveSignal(ctx, "hdmi?port=99")
+	is.True(err != nil)
+
+	is.NoErr(disp.SetPower(ctx, false))
+
+	active, err = disp.ActiveSignal(ctx, "")
+	is.NoErr(err)
+	is.True(!active)
+
+	active, err = disp.ActiveSignal(ctx, "hdmi?port=1")
+	is.NoErr(err)
+	is.True(!active)
+}
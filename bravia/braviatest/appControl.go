@@ -0,0 +1,68 @@
+package braviatest
+
+import "strings"
+
+// App is an application installed on the display.
+type App struct {
+	Title string `json:"title"`
+	URI   string `json:"uri"`
+	Icon  string `json:"icon"`
+}
+
+const _browserURI = "localapp://webappruntime?url="
+
+func defaultApps() []App {
+	return []App{
+		{Title: "Netflix", URI: "com.sony.dtv.com.netflix.ninja.com.netflix.ninja.MainActivity", Icon: "http://192.0.2.1/netflix.png"},
+		{Title: "YouTube", URI: "com.sony.dtv.com.google.android.youtube.tv.com.google.android.apps.youtube.tv.activity.ShellActivity", Icon: "http://192.0.2.1/youtube.png"},
+		{Title: "Signage", URI: "com.sony.dtv.com.example.signage.com.example.signage.MainActivity", Icon: "http://192.0.2.1/signage.png"},
+	}
+}
+
+func getApplicationList(s *Server, req request) ([]interface{}, rpcError) {
+	return []interface{}{append([]App{}, s.state.Apps...)}, 0
+}
+
+func setActiveApp(s *Server, req request) ([]interface{}, rpcError) {
+	var params struct {
+		URI string `json:"uri"`
+	}
+
+	if code := decodeParams(req, &params); code != 0 {
+		return nil, code
+	}
+
+	if strings.HasPrefix(params.URI, _browserURI) {
+		s.state.ActiveApp = params.URI
+		return nil, 0
+	}
+
+	for _, app := range s.state.Apps {
+		if app.URI == params.URI {
+			s.state.ActiveApp = params.URI
+			return nil, 0
+		}
+	}
+
+	return nil, CodeIllegalArgument
+}
+
+func terminateApps(s *Server, req request) ([]interface{}, rpcError) {
+	s.state.ActiveApp = ""
+	return nil, 0
+}
+
+func getApplicationStatusList(s *Server, req request) ([]interface{}, rpcError) {
+	browsing := "off"
+	if strings.HasPrefix(s.state.ActiveApp, _browserURI) {
+		browsing = "on"
+	}
+
+	return []interface{}{
+		[]map[string]string{
+			{"name": "textInput", "status": "off"},
+			{"name": "cursorDisplay", "status": browsing},
+			{"name": "webBrowse", "status": browsing},
+		},
+	}, 0
+}
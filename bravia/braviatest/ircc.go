@@ -0,0 +1,96 @@
+package braviatest
+
+import (
+	"encoding/xml"
+	"net/http"
+)
+
+// RemoteCode is a remote control key returned by getRemoteControllerInfo.
+type RemoteCode struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// RemoteCodes are the remote control keys the emulator supports.
+var RemoteCodes = []RemoteCode{
+	{Name: "PowerOff", Value: "AAAAAQAAAAEAAAAvAw=="},
+	{Name: "Input", Value: "AAAAAQAAAAEAAAAlAw=="},
+	{Name: "VolumeUp", Value: "AAAAAQAAAAEAAAASAw=="},
+	{Name: "VolumeDown", Value: "AAAAAQAAAAEAAAATAw=="},
+	{Name: "Mute", Value: "AAAAAQAAAAEAAAAUAw=="},
+	{Name: "ChannelUp", Value: "AAAAAQAAAAEAAAAQAw=="},
+	{Name: "ChannelDown", Value: "AAAAAQAAAAEAAAARAw=="},
+	{Name: "Home", Value: "AAAAAQAAAAEAAABgAw=="},
+	{Name: "Confirm", Value: "AAAAAQAAAAEAAABlAw=="},
+	{Name: "Up", Value: "AAAAAQAAAAEAAAB0Aw=="},
+	{Name: "Down", Value: "AAAAAQAAAAEAAAB1Aw=="},
+	{Name: "Left", Value: "AAAAAQAAAAEAAAA0Aw=="},
+	{Name: "Right", Value: "AAAAAQAAAAEAAAAzAw=="},
+	{Name: "Play", Value: "AAAAAgAAAJcAAAAaAw=="},
+	{Name: "Pause", Value: "AAAAAgAAAJcAAAAZAw=="},
+	{Name: "Netflix", Value: "AAAAAgAAABoAAAB8Aw=="},
+}
+
+const irccFault = `<?xml version="1.0"?>
+<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">
+	<s:Body>
+		<s:Fault>
+			<faultcode>s:Client</faultcode>
+			<faultstring>UPnPError</faultstring>
+			<detail>
+				<UPnPError xmlns="urn:schemas-upnp-org:control-1-0">
+					<errorCode>800</errorCode>
+					<errorDescription>Cannot accept the IRCC Code</errorDescription>
+				</UPnPError>
+			</detail>
+		</s:Fault>
+	</s:Body>
+</s:Envelope>`
+
+func getRemoteControllerInfo(s *Server, req request) ([]interface{}, rpcError) {
+	return []interface{}{
+		map[string]interface{}{
+			"bundled": true,
+			"type":    "IR_REMOTE_BUNDLE_TYPE_AEP_N",
+		},
+		RemoteCodes,
+	}, 0
+}
+
+func (s *Server) handleIRCC(w http.ResponseWriter, r *http.Request) {
+	s.dropIfUnreachable()
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.psk != "" && r.Header.Get("X-Auth-PSK") != s.psk {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	var envelope struct {
+		Code string `xml:"Body>X_SendIRCC>IRCCCode"`
+	}
+
+	if err := xml.NewDecoder(r.Body).Decode(&envelope); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, code := range RemoteCodes {
+		if code.Value == envelope.Code {
+			s.state.Pressed = append(s.state.Pressed, code.Name)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/xml; charset=UTF-8")
+	w.WriteHeader(http.StatusInternalServerError)
+	w.Write([]byte(irccFault))
+}
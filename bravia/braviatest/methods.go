@@ -0,0 +1,305 @@
+package braviatest
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type method struct {
+	versions []string
+
+	// needsPower is true if the method fails with CodeDisplayOff while the display is in standby
+	needsPower bool
+
+	handle func(s *Server, req request) ([]interface{}, rpcError)
+}
+
+var handlers = map[string]map[string]method{
+	"system": {
+		"getPowerStatus":       {versions: []string{"1.0"}, handle: getPowerStatus},
+		"setPowerStatus":       {versions: []string{"1.0"}, handle: setPowerStatus},
+		"getPowerSavingMode":   {versions: []string{"1.0"}, handle: getPowerSavingMode},
+		"setPowerSavingMode":   {versions: []string{"1.0"}, handle: setPowerSavingMode},
+		"getSystemInformation": {versions: []string{"1.0"}, handle: getSystemInformation},
+
+		"getRemoteControllerInfo": {versions: []string{"1.0"}, handle: getRemoteControllerInfo},
+
+		"getWolMode":         {versions: []string{"1.0"}, handle: getWolMode},
+		"setWolMode":         {versions: []string{"1.0"}, handle: setWolMode},
+		"getNetworkSettings": {versions: []string{"1.0"}, handle: getNetworkSettings},
+	},
+	"audio": {
+		"getVolumeInformation": {versions: []string{"1.0"}, needsPower: true, handle: getVolumeInformation},
+		"setAudioVolume":       {versions: []string{"1.0", "1.2"}, needsPower: true, handle: setAudioVolume},
+		"setAudioMute":         {versions: []string{"1.0"}, needsPower: true, handle: setAudioMute},
+		"getSoundSettings":     {versions: []string{"1.1"}, needsPower: true, handle: getSoundSettings},
+		"setSoundSettings":     {versions: []string{"1.1"}, needsPower: true, handle: setSoundSettings},
+		"getSpeakerSettings":   {versions: []string{"1.0"}, needsPower: true, handle: getSpeakerSettings},
+		"setSpeakerSettings":   {versions: []string{"1.0"}, needsPower: true, handle: setSpeakerSettings},
+	},
+	"avContent": {
+		"getPlayingContentInfo":          {versions: []string{"1.0"}, needsPower: true, handle: getPlayingContentInfo},
+		"setPlayContent":                 {versions: []string{"1.0"}, handle: setPlayContent},
+		"getCurrentExternalInputsStatus": {versions: []string{"1.0", "1.1"}, handle: getCurrentExternalInputsStatus},
+	},
+	"video": {
+		"getPictureQualitySettings": {versions: []string{"1.0"}, needsPower: true, handle: getPictureQualitySettings},
+		"setPictureQualitySettings": {versions: []string{"1.0"}, needsPower: true, handle: setPictureQualitySettings},
+	},
+	"appControl": {
+		"getApplicationList":       {versions: []string{"1.0"}, handle: getApplicationList},
+		"setActiveApp":             {versions: []string{"1.0"}, needsPower: true, handle: setActiveApp},
+		"terminateApps":            {versions: []string{"1.0"}, needsPower: true, handle: terminateApps},
+		"getApplicationStatusList": {versions: []string{"1.0"}, handle: getApplicationStatusList},
+	},
+	"guide": {
+		"getSupportedApiInfo": {versions: []string{"1.0"}, handle: getSupportedAPIInfo},
+	},
+}
+
+func getPowerStatus(s *Server, req request) ([]interface{}, rpcError) {
+	status := "standby"
+	if s.state.Power {
+		status = "active"
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"status": status,
+		},
+	}, 0
+}
+
+func setPowerStatus(s *Server, req request) ([]interface{}, rpcError) {
+	var params struct {
+		Status *bool `json:"status"`
+	}
+
+	if code := decodeParams(req, &params); code != 0 {
+		return nil, code
+	}
+
+	if params.Status == nil {
+		return nil, CodeIllegalArgument
+	}
+
+	s.state.Power = *params.Status
+	return nil, 0
+}
+
+func getPowerSavingMode(s *Server, req request) ([]interface{}, rpcError) {
+	return []interface{}{
+		map[string]interface{}{
+			"mode": s.state.PowerSavingMode,
+		},
+	}, 0
+}
+
+func setPowerSavingMode(s *Server, req request) ([]interface{}, rpcError) {
+	var params struct {
+		Mode string `json:"mode"`
+	}
+
+	if code := decodeParams(req, &params); code != 0 {
+		return nil, code
+	}
+
+	switch params.Mode {
+	case "off", "low", "high", "pictureOff":
+	default:
+		return nil, CodeIllegalArgument
+	}
+
+	s.state.PowerSavingMode = params.Mode
+	return nil, 0
+}
+
+func getSystemInformation(s *Server, req request) ([]interface{}, rpcError) {
+	return []interface{}{s.state.Info}, 0
+}
+
+func getVolumeInformation(s *Server, req request) ([]interface{}, rpcError) {
+	return []interface{}{append([]Volume{}, s.state.Volumes...)}, 0
+}
+
+func setAudioVolume(s *Server, req request) ([]interface{}, rpcError) {
+	var params struct {
+		Target string `json:"target"`
+		Volume string `json:"volume"`
+		UI     string `json:"ui"`
+	}
+
+	if code := decodeParams(req, &params); code != 0 {
+		return nil, code
+	}
+
+	// ui was added in 1.2
+	if req.Version == "1.0" && params.UI != "" {
+		return nil, CodeIllegalArgument
+	}
+
+	relative := strings.HasPrefix(params.Volume, "+") || strings.HasPrefix(params.Volume, "-")
+
+	level, err := strconv.Atoi(params.Volume)
+	if err != nil {
+		return nil, CodeIllegalArgument
+	}
+
+	found := false
+	for i := range s.state.Volumes {
+		vol := &s.state.Volumes[i]
+		if params.Target != "" && params.Target != vol.Target {
+			continue
+		}
+
+		found = true
+
+		next := level
+		if relative {
+			next = vol.Volume + level
+		}
+
+		switch {
+		case next < vol.MinVolume && relative:
+			next = vol.MinVolume
+		case next > vol.MaxVolume && relative:
+			next = vol.MaxVolume
+		case next < vol.MinVolume, next > vol.MaxVolume:
+			return nil, CodeIllegalArgument
+		}
+
+		vol.Volume = next
+	}
+
+	if !found {
+		return nil, CodeIllegalTarget
+	}
+
+	return nil, 0
+}
+
+func setAudioMute(s *Server, req request) ([]interface{}, rpcError) {
+	var params struct {
+		Status *bool `json:"status"`
+	}
+
+	if code := decodeParams(req, &params); code != 0 {
+		return nil, code
+	}
+
+	if params.Status == nil {
+		return nil, CodeIllegalArgument
+	}
+
+	for i := range s.state.Volumes {
+		s.state.Volumes[i].Mute = *params.Status
+	}
+
+	return nil, 0
+}
+
+func getPlayingContentInfo(s *Server, req request) ([]interface{}, rpcError) {
+	title := ""
+	for _, input := range s.state.Inputs {
+		if input.URI == s.state.Input {
+			title = input.Title
+			break
+		}
+	}
+
+	source := s.state.Input
+	if i := strings.Index(source, "?"); i >= 0 {
+		source = source[:i]
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"uri":    s.state.Input,
+			"source": source,
+			"title":  title,
+		},
+	}, 0
+}
+
+func setPlayContent(s *Server, req request) ([]interface{}, rpcError) {
+	var params struct {
+		URI string `json:"uri"`
+	}
+
+	if code := decodeParams(req, &params); code != 0 {
+		return nil, code
+	}
+
+	for _, input := range s.state.Inputs {
+		if input.URI == params.URI {
+			s.state.Input = params.URI
+			return nil, 0
+		}
+	}
+
+	return nil, CodeIllegalArgument
+}
+
+func getCurrentExternalInputsStatus(s *Server, req request) ([]interface{}, rpcError) {
+	return []interface{}{append([]Input{}, s.state.Inputs...)}, 0
+}
+
+type apiInfo struct {
+	Service string    `json:"service"`
+	APIs    []apiName `json:"apis"`
+}
+
+type apiName struct {
+	Name     string       `json:"name"`
+	Versions []apiVersion `json:"versions"`
+}
+
+type apiVersion struct {
+	Version string `json:"version"`
+}
+
+func getSupportedAPIInfo(s *Server, req request) ([]interface{}, rpcError) {
+	var params struct {
+		Services []string `json:"services"`
+	}
+
+	if code := decodeParams(req, &params); code != 0 {
+		return nil, code
+	}
+
+	services := params.Services
+	if len(services) == 0 {
+		for service := range s.apis {
+			services = append(services, service)
+		}
+
+		sort.Strings(services)
+	}
+
+	infos := []apiInfo{}
+	for _, service := range services {
+		methods, ok := s.apis[service]
+		if !ok {
+			continue
+		}
+
+		info := apiInfo{Service: service}
+		for name, versions := range methods {
+			api := apiName{Name: name}
+			for _, v := range versions {
+				api.Versions = append(api.Versions, apiVersion{Version: v})
+			}
+
+			info.APIs = append(info.APIs, api)
+		}
+
+		sort.Slice(info.APIs, func(i, j int) bool {
+			return info.APIs[i].Name < info.APIs[j].Name
+		})
+
+		infos = append(infos, info)
+	}
+
+	return []interface{}{infos}, 0
+}
@@ -0,0 +1,248 @@
+package braviatest
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// notifications are the notifications each service can send over a websocket.
+var notifications = map[string][]string{
+	"system":    {"notifyPowerStatus"},
+	"audio":     {"notifyVolumeInformation"},
+	"avContent": {"notifyPlayingContentInfo"},
+}
+
+type subscriber struct {
+	service string
+	enabled map[string]bool
+	send    chan interface{}
+	conn    *websocket.Conn
+
+	// hung subscribers don't answer pings or get notifications. It is guarded by Server.mu.
+	hung bool
+}
+
+type notification struct {
+	Method  string        `json:"method"`
+	Version string        `json:"version"`
+	Params  []interface{} `json:"params"`
+}
+
+type notificationName struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+var upgrader = websocket.Upgrader{}
+
+// HangNotifications makes every open websocket connection stop answering pings and sending
+// notifications without closing it, as if the display was unplugged. New connections aren't affected.
+func (s *Server) HangNotifications() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subs {
+		sub.hung = true
+	}
+}
+
+// CloseNotifications closes every open websocket connection, as if the display dropped off the network.
+func (s *Server) CloseNotifications() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subs {
+		sub.conn.Close()
+	}
+}
+
+func (s *Server) handleWebSocket(service string, w http.ResponseWriter, r *http.Request) {
+	if s.psk != "" && r.Header.Get("X-Auth-PSK") != s.psk {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	sub := &subscriber{
+		service: service,
+		enabled: make(map[string]bool),
+		send:    make(chan interface{}, 32),
+		conn:    conn,
+	}
+
+	s.mu.Lock()
+	s.subs[sub] = struct{}{}
+	s.mu.Unlock()
+
+	conn.SetPingHandler(func(data string) error {
+		s.mu.Lock()
+		hung := sub.hung
+		s.mu.Unlock()
+
+		if hung {
+			return nil
+		}
+
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+
+	done := make(chan struct{})
+	defer func() {
+		s.mu.Lock()
+		delete(s.subs, sub)
+		s.mu.Unlock()
+
+		close(done)
+		conn.Close()
+	}()
+
+	go func() {
+		for {
+			select {
+			case msg := <-sub.send:
+				if err := conn.WriteJSON(msg); err != nil {
+					conn.Close()
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for {
+		var req request
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		var result []interface{}
+		var code rpcError
+		if req.Method == "switchNotifications" {
+			result, code = s.switchNotifications(sub, req)
+		} else {
+			result, code = s.call(service, req)
+		}
+		s.mu.Unlock()
+
+		resp := response{ID: req.ID}
+		switch {
+		case code != 0:
+			resp.Error = errorResult(code)
+		case result == nil:
+			resp.Result = []interface{}{}
+		default:
+			resp.Result = result
+		}
+
+		sub.send <- resp
+	}
+}
+
+// switchNotifications must be called with s.mu held.
+func (s *Server) switchNotifications(sub *subscriber, req request) ([]interface{}, rpcError) {
+	var params struct {
+		Enabled  []notificationName `json:"enabled"`
+		Disabled []notificationName `json:"disabled"`
+	}
+
+	if len(req.Params) > 0 {
+		if err := json.Unmarshal(req.Params[0], &params); err != nil {
+			return nil, CodeIllegalArgument
+		}
+	}
+
+	available := make(map[string]bool)
+	for _, name := range notifications[sub.service] {
+		available[name] = true
+	}
+
+	for _, n := range params.Enabled {
+		if !available[n.Name] || n.Version != "1.0" {
+			return nil, CodeIllegalArgument
+		}
+
+		sub.enabled[n.Name] = true
+	}
+
+	for _, n := range params.Disabled {
+		delete(sub.enabled, n.Name)
+	}
+
+	enabled := []notificationName{}
+	disabled := []notificationName{}
+	for _, name := range notifications[sub.service] {
+		n := notificationName{Name: name, Version: "1.0"}
+		if sub.enabled[name] {
+			enabled = append(enabled, n)
+		} else {
+			disabled = append(disabled, n)
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"enabled":  enabled,
+			"disabled": disabled,
+		},
+	}, 0
+}
+
+// notifyChanges sends notifications for anything that changed since before.
+// It must be called with s.mu held.
+func (s *Server) notifyChanges(before State) {
+	if before.Power != s.state.Power {
+		status := "standby"
+		if s.state.Power {
+			status = "active"
+		}
+
+		s.notify("notifyPowerStatus", map[string]interface{}{
+			"status": status,
+		})
+	}
+
+	if before.Input != s.state.Input && s.state.Power {
+		res, _ := getPlayingContentInfo(s, request{})
+		s.notify("notifyPlayingContentInfo", res[0])
+	}
+
+	if !reflect.DeepEqual(before.Volumes, s.state.Volumes) {
+		for _, vol := range s.state.Volumes {
+			s.notify("notifyVolumeInformation", map[string]interface{}{
+				"target": vol.Target,
+				"volume": vol.Volume,
+				"mute":   vol.Mute,
+			})
+		}
+	}
+}
+
+// notify must be called with s.mu held.
+func (s *Server) notify(name string, params interface{}) {
+	for sub := range s.subs {
+		if !sub.enabled[name] || sub.hung {
+			continue
+		}
+
+		n := notification{
+			Method:  name,
+			Version: "1.0",
+			Params:  []interface{}{params},
+		}
+
+		select {
+		case sub.send <- n:
+		default:
+		}
+	}
+}
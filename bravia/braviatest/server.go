@@ -0,0 +1,337 @@
+/*
+Package braviatest provides an in-process emulator of the Sony BRAVIA REST API, for use in tests
+that would otherwise need a real display.
+
+The emulator serves the system, audio, avContent, video, appControl, and guide services over
+JSON-RPC, their websocket notifications, and the IRCC remote control endpoint, the same way a
+display does, so a bravia.Display can point its Address at Server.Address() unchanged.
+*/
+package braviatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// Error codes returned by the emulator. These match the codes documented
+// at https://pro-bravia.sony.net/develop/integrate/rest-api/spec/errorcode-list/index.html.
+const (
+	CodeAny                = 1
+	CodeIllegalArgument    = 3
+	CodeIllegalRequest     = 5
+	CodeIllegalState       = 7
+	CodeNoSuchMethod       = 12
+	CodeUnsupportedVersion = 14
+	CodeForbidden          = 403
+	CodeDisplayOff         = 40005
+	CodeIllegalTarget      = 40800
+	CodeUnsupportedTarget  = 40801
+)
+
+var errorReasons = map[int]string{
+	CodeAny:                "Any",
+	CodeIllegalArgument:    "Illegal Argument",
+	CodeIllegalRequest:     "Illegal Request",
+	CodeIllegalState:       "Illegal State",
+	CodeNoSuchMethod:       "No Such Method",
+	CodeUnsupportedVersion: "Unsupported Version",
+	CodeForbidden:          "Forbidden",
+	CodeDisplayOff:         "Display Is Turned off",
+	CodeIllegalTarget:      "Illegal Target",
+	CodeUnsupportedTarget:  "Unsupported Target",
+}
+
+// Server is an emulated BRAVIA display.
+type Server struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	psk    string
+	state  State
+	errors map[string]int
+	ignore map[string]bool
+	apis   map[string]map[string][]string
+	subs   map[*subscriber]struct{}
+	wol    net.PacketConn
+}
+
+// NewServer starts and returns a new emulated display. If psk is not empty, every
+// request must carry a matching X-Auth-PSK header. The caller should call Close when finished.
+func NewServer(psk string) *Server {
+	s := newServer(psk)
+	s.Server = httptest.NewServer(s.mux())
+	return s
+}
+
+// NewTLSServer starts and returns a new emulated display that serves HTTPS, like displays
+// with firmware that only accepts HTTPS. Use the Client method of the embedded httptest.Server
+// for an *http.Client that trusts it. The caller should call Close when finished.
+func NewTLSServer(psk string) *Server {
+	s := newServer(psk)
+	s.Server = httptest.NewTLSServer(s.mux())
+	return s
+}
+
+func newServer(psk string) *Server {
+	s := &Server{
+		psk:    psk,
+		state:  DefaultState(),
+		errors: make(map[string]int),
+		ignore: make(map[string]bool),
+		apis:   make(map[string]map[string][]string),
+		subs:   make(map[*subscriber]struct{}),
+	}
+
+	for service, methods := range handlers {
+		s.apis[service] = make(map[string][]string, len(methods))
+		for name, m := range methods {
+			s.apis[service][name] = append([]string(nil), m.versions...)
+		}
+	}
+
+	wol, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		panic(fmt.Sprintf("braviatest: failed to listen for Wake-on-LAN: %v", err))
+	}
+
+	s.wol = wol
+	go s.listenWakeOnLAN()
+
+	return s
+}
+
+func (s *Server) mux() *http.ServeMux {
+	mux := http.NewServeMux()
+	for service := range handlers {
+		mux.HandleFunc("/sony/"+service, s.handle(service))
+	}
+
+	mux.HandleFunc("/sony/IRCC", s.handleIRCC)
+	return mux
+}
+
+// Close shuts down the server, blocking until all outstanding requests have completed.
+func (s *Server) Close() {
+	s.wol.Close()
+	s.Server.Close()
+}
+
+// Address returns the host:port of the server, suitable for bravia.Display.Address.
+func (s *Server) Address() string {
+	return strings.TrimPrefix(strings.TrimPrefix(s.URL, "http://"), "https://")
+}
+
+// State returns a copy of the current state of the display.
+func (s *Server) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state.copy()
+}
+
+// SetState calls f with the current state of the display, allowing it to be modified.
+// Notifications are sent to subscribers for anything f changes.
+func (s *Server) SetState(f func(*State)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before := s.state.copy()
+	f(&s.state)
+	s.notifyChanges(before)
+}
+
+// SetError causes every following call to method to fail with the given error code,
+// until ClearError is called.
+func (s *Server) SetError(method string, code int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errors[method] = code
+}
+
+// ClearError removes an error set by SetError.
+func (s *Server) ClearError(method string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.errors, method)
+}
+
+// SetIgnored causes calls to method to succeed without doing anything, like a display
+// that is too busy to act on a request, until it is called again with ignored false.
+func (s *Server) SetIgnored(method string, ignored bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !ignored {
+		delete(s.ignore, method)
+		return
+	}
+
+	s.ignore[method] = true
+}
+
+// SetVersions overrides the versions of method that the server supports. Calling
+// it with no versions removes the method entirely.
+func (s *Server) SetVersions(service, method string, versions ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.apis[service]; !ok {
+		s.apis[service] = make(map[string][]string)
+	}
+
+	if len(versions) == 0 {
+		delete(s.apis[service], method)
+		return
+	}
+
+	s.apis[service][method] = versions
+}
+
+type request struct {
+	ID      int               `json:"id"`
+	Method  string            `json:"method"`
+	Version string            `json:"version"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+type response struct {
+	ID     int           `json:"id"`
+	Result []interface{} `json:"result,omitempty"`
+	Error  []interface{} `json:"error,omitempty"`
+}
+
+type rpcError int
+
+func (s *Server) handle(service string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.dropIfUnreachable()
+
+		if websocket.IsWebSocketUpgrade(r) {
+			s.handleWebSocket(service, w, r)
+			return
+		}
+
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeResponse(w, http.StatusOK, response{Error: errorResult(CodeIllegalRequest)})
+			return
+		}
+
+		if s.psk != "" && r.Header.Get("X-Auth-PSK") != s.psk {
+			writeResponse(w, http.StatusForbidden, response{ID: req.ID, Error: errorResult(CodeForbidden)})
+			return
+		}
+
+		s.mu.Lock()
+		result, code := s.call(service, req)
+		s.mu.Unlock()
+
+		resp := response{ID: req.ID}
+		switch {
+		case code != 0:
+			resp.Error = errorResult(code)
+		case result == nil:
+			resp.Result = []interface{}{}
+		default:
+			resp.Result = result
+		}
+
+		writeResponse(w, http.StatusOK, resp)
+	}
+}
+
+// dropIfUnreachable aborts the current request, like a display that isn't on
+// the network would, if the display is in deep standby.
+func (s *Server) dropIfUnreachable() {
+	s.mu.Lock()
+	unreachable := s.state.DeepStandby
+	s.mu.Unlock()
+
+	if unreachable {
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// call must be called with s.mu held.
+func (s *Server) call(service string, req request) ([]interface{}, rpcError) {
+	versions, ok := s.apis[service][req.Method]
+	if !ok {
+		return nil, CodeNoSuchMethod
+	}
+
+	supported := false
+	for _, v := range versions {
+		if v == req.Version {
+			supported = true
+			break
+		}
+	}
+
+	if !supported {
+		return nil, CodeUnsupportedVersion
+	}
+
+	if code, ok := s.errors[req.Method]; ok {
+		return nil, rpcError(code)
+	}
+
+	m, ok := handlers[service][req.Method]
+	if !ok {
+		return nil, CodeNoSuchMethod
+	}
+
+	if s.ignore[req.Method] {
+		return nil, 0
+	}
+
+	if m.needsPower && !s.state.Power {
+		return nil, CodeDisplayOff
+	}
+
+	before := s.state.copy()
+	defer s.notifyChanges(before)
+
+	return m.handle(s, req)
+}
+
+func errorResult(code rpcError) []interface{} {
+	reason, ok := errorReasons[int(code)]
+	if !ok {
+		reason = "Error"
+	}
+
+	return []interface{}{int(code), reason}
+}
+
+func writeResponse(w http.ResponseWriter, status int, resp response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// decodeParams decodes the first element of params into v.
+func decodeParams(req request, v interface{}) rpcError {
+	if len(req.Params) < 1 {
+		return CodeIllegalArgument
+	}
+
+	if err := json.Unmarshal(req.Params[0], v); err != nil {
+		return CodeIllegalArgument
+	}
+
+	return 0
+}
@@ -0,0 +1,86 @@
+package braviatest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func post(t *testing.T, s *Server, psk, service, body string) (int, response) {
+	t.Helper()
+	is := is.New(t)
+
+	req, err := http.NewRequest(http.MethodPost, s.URL+"/sony/"+service, bytes.NewBufferString(body))
+	is.NoErr(err)
+	req.Header.Set("X-Auth-PSK", psk)
+
+	resp, err := s.Client().Do(req)
+	is.NoErr(err)
+	defer resp.Body.Close()
+
+	var res response
+	is.NoErr(json.NewDecoder(resp.Body).Decode(&res))
+	return resp.StatusCode, res
+}
+
+func TestPreSharedKey(t *testing.T) {
+	is := is.New(t)
+
+	s := NewServer("1234")
+	defer s.Close()
+
+	code, res := post(t, s, "4321", "system", `{"id":1,"method":"getPowerStatus","version":"1.0","params":[]}`)
+	is.Equal(code, http.StatusForbidden)
+	is.Equal(res.Error[0], float64(CodeForbidden))
+
+	code, res = post(t, s, "1234", "system", `{"id":2,"method":"getPowerStatus","version":"1.0","params":[]}`)
+	is.Equal(code, http.StatusOK)
+	is.Equal(res.ID, 2)
+	is.Equal(len(res.Error), 0)
+}
+
+func TestSetError(t *testing.T) {
+	is := is.New(t)
+
+	s := NewServer("")
+	defer s.Close()
+
+	s.SetState(func(state *State) {
+		state.Power = true
+	})
+
+	s.SetError("getVolumeInformation", CodeDisplayOff)
+
+	_, res := post(t, s, "", "audio", `{"id":1,"method":"getVolumeInformation","version":"1.0","params":[]}`)
+	is.Equal(res.Error[0], float64(CodeDisplayOff))
+
+	s.ClearError("getVolumeInformation")
+
+	_, res = post(t, s, "", "audio", `{"id":1,"method":"getVolumeInformation","version":"1.0","params":[]}`)
+	is.Equal(len(res.Error), 0)
+}
+
+func TestRelativeVolume(t *testing.T) {
+	is := is.New(t)
+
+	s := NewServer("")
+	defer s.Close()
+
+	s.SetState(func(state *State) {
+		state.Power = true
+	})
+
+	_, res := post(t, s, "", "audio", `{"id":1,"method":"setAudioVolume","version":"1.2","params":[{"target":"speaker","volume":"+5","ui":"off"}]}`)
+	is.Equal(len(res.Error), 0)
+	is.Equal(s.State().Volumes[0].Volume, 25)
+
+	_, res = post(t, s, "", "audio", `{"id":1,"method":"setAudioVolume","version":"1.2","params":[{"target":"speaker","volume":"-50"}]}`)
+	is.Equal(len(res.Error), 0)
+	is.Equal(s.State().Volumes[0].Volume, 0)
+
+	_, res = post(t, s, "", "audio", `{"id":1,"method":"setAudioVolume","version":"1.2","params":[{"target":"speaker","volume":"101"}]}`)
+	is.Equal(res.Error[0], float64(CodeIllegalArgument))
+}
@@ -0,0 +1,112 @@
+package braviatest
+
+// Setting is a sound or speaker setting.
+type Setting struct {
+	Target       string `json:"target"`
+	CurrentValue string `json:"currentValue"`
+
+	// Candidates are the values the setting accepts. If empty, any value is accepted.
+	Candidates []string `json:"-"`
+}
+
+func defaultSoundSettings() []Setting {
+	return []Setting{
+		{Target: "outputTerminal", CurrentValue: "speaker", Candidates: []string{"speaker", "speaker_hdmi", "hdmi", "audioSystem", "headphone"}},
+	}
+}
+
+func defaultSpeakerSettings() []Setting {
+	return []Setting{
+		{Target: "tvPosition", CurrentValue: "tableTop", Candidates: []string{"tableTop", "wallMount"}},
+		{Target: "subwooferLevel", CurrentValue: "12"},
+		{Target: "subwooferFreq", CurrentValue: "10"},
+		{Target: "subwooferPhase", CurrentValue: "normal", Candidates: []string{"normal", "reverse"}},
+		{Target: "subwooferPower", CurrentValue: "on", Candidates: []string{"on", "off"}},
+	}
+}
+
+func getSoundSettings(s *Server, req request) ([]interface{}, rpcError) {
+	return getSettings(s.state.SoundSettings, req)
+}
+
+func setSoundSettings(s *Server, req request) ([]interface{}, rpcError) {
+	return setSettings(s.state.SoundSettings, req)
+}
+
+func getSpeakerSettings(s *Server, req request) ([]interface{}, rpcError) {
+	return getSettings(s.state.SpeakerSettings, req)
+}
+
+func setSpeakerSettings(s *Server, req request) ([]interface{}, rpcError) {
+	return setSettings(s.state.SpeakerSettings, req)
+}
+
+func getSettings(settings []Setting, req request) ([]interface{}, rpcError) {
+	var params struct {
+		Target string `json:"target"`
+	}
+
+	if code := decodeParams(req, &params); code != 0 {
+		return nil, code
+	}
+
+	list := []Setting{}
+	for _, setting := range settings {
+		if params.Target == "" || params.Target == setting.Target {
+			list = append(list, setting)
+		}
+	}
+
+	if len(list) == 0 {
+		return nil, CodeIllegalTarget
+	}
+
+	return []interface{}{list}, 0
+}
+
+func setSettings(settings []Setting, req request) ([]interface{}, rpcError) {
+	var params struct {
+		Settings []struct {
+			Target string `json:"target"`
+			Value  string `json:"value"`
+		} `json:"settings"`
+	}
+
+	if code := decodeParams(req, &params); code != 0 {
+		return nil, code
+	}
+
+	for _, set := range params.Settings {
+		found := false
+
+		for i := range settings {
+			setting := &settings[i]
+			if setting.Target != set.Target {
+				continue
+			}
+
+			found = true
+			if len(setting.Candidates) > 0 && !contains(setting.Candidates, set.Value) {
+				return nil, CodeIllegalArgument
+			}
+
+			setting.CurrentValue = set.Value
+		}
+
+		if !found {
+			return nil, CodeIllegalTarget
+		}
+	}
+
+	return nil, 0
+}
+
+func contains(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,105 @@
+package braviatest
+
+// State is the emulated state of a display.
+type State struct {
+	Power           bool
+	PowerSavingMode string
+
+	// DeepStandby makes the display unreachable, dropping every request, until it
+	// receives a Wake-on-LAN packet. WakeOnLAN must be true for the packet to wake it.
+	DeepStandby bool
+	WakeOnLAN   bool
+
+	// Input is the uri of the current input, ie "extInput:hdmi?port=1".
+	Input   string
+	Inputs  []Input
+	Volumes []Volume
+
+	SoundSettings   []Setting
+	SpeakerSettings []Setting
+	PictureSettings []PictureSetting
+
+	Apps []App
+
+	// ActiveApp is the uri of the open application, or empty if none is open.
+	ActiveApp string
+
+	Info SystemInfo
+
+	// Pressed are the names of the remote control keys sent over IRCC, in order.
+	Pressed []string
+}
+
+// Input is an external input on the display.
+type Input struct {
+	URI        string `json:"uri"`
+	Title      string `json:"title"`
+	Connection bool   `json:"connection"`
+	Label      string `json:"label"`
+	Icon       string `json:"icon"`
+	Status     string `json:"status"`
+}
+
+// Volume is the volume state of a single output target.
+type Volume struct {
+	Target    string `json:"target"`
+	Volume    int    `json:"volume"`
+	Mute      bool   `json:"mute"`
+	MaxVolume int    `json:"maxVolume"`
+	MinVolume int    `json:"minVolume"`
+}
+
+// SystemInfo is returned by getSystemInformation.
+type SystemInfo struct {
+	Product    string `json:"product"`
+	Language   string `json:"language"`
+	Model      string `json:"model"`
+	Serial     string `json:"serial"`
+	MACAddress string `json:"macAddr"`
+	Name       string `json:"name"`
+	Generation string `json:"generation"`
+}
+
+// DefaultState returns the state a new Server starts with: a display in standby
+// on hdmi 1, with four hdmi inputs and a speaker and headphone target.
+func DefaultState() State {
+	return State{
+		PowerSavingMode: "off",
+		WakeOnLAN:       true,
+		Input:           "extInput:hdmi?port=1",
+		Inputs: []Input{
+			{URI: "extInput:hdmi?port=1", Title: "HDMI 1", Connection: true, Icon: "meta:hdmi", Status: "true"},
+			{URI: "extInput:hdmi?port=2", Title: "HDMI 2", Connection: true, Icon: "meta:hdmi", Status: "true"},
+			{URI: "extInput:hdmi?port=3", Title: "HDMI 3", Connection: false, Icon: "meta:hdmi", Status: "false"},
+			{URI: "extInput:hdmi?port=4", Title: "HDMI 4/ARC", Connection: false, Icon: "meta:hdmi", Status: "false"},
+		},
+		Volumes: []Volume{
+			{Target: "speaker", Volume: 20, MaxVolume: 100, MinVolume: 0},
+			{Target: "headphone", Volume: 15, MaxVolume: 100, MinVolume: 0},
+		},
+		SoundSettings:   defaultSoundSettings(),
+		SpeakerSettings: defaultSpeakerSettings(),
+		PictureSettings: defaultPictureSettings(),
+		Apps:            defaultApps(),
+		Info: SystemInfo{
+			Product:    "TV",
+			Language:   "eng",
+			Model:      "XBR-65X900H",
+			Serial:     "1234567",
+			MACAddress: "00:00:5e:00:53:01",
+			Name:       "BRAVIA",
+			Generation: "5.4.0",
+		},
+	}
+}
+
+func (s State) copy() State {
+	s.Inputs = append([]Input(nil), s.Inputs...)
+	s.Volumes = append([]Volume(nil), s.Volumes...)
+	s.SoundSettings = append([]Setting(nil), s.SoundSettings...)
+	s.SpeakerSettings = append([]Setting(nil), s.SpeakerSettings...)
+	s.PictureSettings = append([]PictureSetting(nil), s.PictureSettings...)
+	s.Apps = append([]App(nil), s.Apps...)
+	s.Pressed = append([]string(nil), s.Pressed...)
+	return s
+}
@@ -0,0 +1,125 @@
+package braviatest
+
+import (
+	"strconv"
+)
+
+// PictureSetting is a picture quality setting returned by getPictureQualitySettings.
+type PictureSetting struct {
+	Target       string             `json:"target"`
+	CurrentValue string             `json:"currentValue"`
+	IsAvailable  bool               `json:"isAvailable"`
+	Candidate    []PictureCandidate `json:"candidate"`
+}
+
+// PictureCandidate is either a single value a setting can be set to, or the range of a numeric setting.
+type PictureCandidate struct {
+	Value string `json:"value,omitempty"`
+	Min   *int   `json:"min,omitempty"`
+	Max   *int   `json:"max,omitempty"`
+	Step  *int   `json:"step,omitempty"`
+}
+
+func pictureRange(min, max int) []PictureCandidate {
+	step := 1
+	return []PictureCandidate{{Min: &min, Max: &max, Step: &step}}
+}
+
+func defaultPictureSettings() []PictureSetting {
+	return []PictureSetting{
+		{
+			Target:       "pictureMode",
+			CurrentValue: "standard",
+			IsAvailable:  true,
+			Candidate: []PictureCandidate{
+				{Value: "vivid"},
+				{Value: "standard"},
+				{Value: "cinema"},
+				{Value: "custom"},
+			},
+		},
+		{Target: "brightness", CurrentValue: "25", IsAvailable: true, Candidate: pictureRange(0, 50)},
+		{Target: "color", CurrentValue: "50", IsAvailable: true, Candidate: pictureRange(0, 100)},
+		{Target: "contrast", CurrentValue: "90", IsAvailable: true, Candidate: pictureRange(0, 100)},
+		{Target: "sharpness", CurrentValue: "50", IsAvailable: true, Candidate: pictureRange(0, 100)},
+	}
+}
+
+func getPictureQualitySettings(s *Server, req request) ([]interface{}, rpcError) {
+	var params struct {
+		Target string `json:"target"`
+	}
+
+	if code := decodeParams(req, &params); code != 0 {
+		return nil, code
+	}
+
+	settings := []PictureSetting{}
+	for _, setting := range s.state.PictureSettings {
+		if params.Target == "" || params.Target == setting.Target {
+			settings = append(settings, setting)
+		}
+	}
+
+	if len(settings) == 0 {
+		return nil, CodeIllegalTarget
+	}
+
+	return []interface{}{settings}, 0
+}
+
+func setPictureQualitySettings(s *Server, req request) ([]interface{}, rpcError) {
+	var params struct {
+		Settings []struct {
+			Target string `json:"target"`
+			Value  string `json:"value"`
+		} `json:"settings"`
+	}
+
+	if code := decodeParams(req, &params); code != 0 {
+		return nil, code
+	}
+
+	for _, set := range params.Settings {
+		found := false
+
+		for i := range s.state.PictureSettings {
+			setting := &s.state.PictureSettings[i]
+			if setting.Target != set.Target {
+				continue
+			}
+
+			found = true
+			if !validPictureValue(*setting, set.Value) {
+				return nil, CodeIllegalArgument
+			}
+
+			setting.CurrentValue = set.Value
+		}
+
+		if !found {
+			return nil, CodeIllegalTarget
+		}
+	}
+
+	return nil, 0
+}
+
+func validPictureValue(setting PictureSetting, value string) bool {
+	for _, c := range setting.Candidate {
+		if c.Min != nil && c.Max != nil {
+			n, err := strconv.Atoi(value)
+			if err == nil && n >= *c.Min && n <= *c.Max {
+				return true
+			}
+
+			continue
+		}
+
+		if c.Value == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,103 @@
+package braviatest
+
+import (
+	"bytes"
+	"net"
+)
+
+// WakeOnLANAddress returns the host:port the server listens for Wake-on-LAN packets on,
+// suitable for bravia.Display.WakeOnLANAddress.
+func (s *Server) WakeOnLANAddress() string {
+	return s.wol.LocalAddr().String()
+}
+
+// listenWakeOnLAN handles Wake-on-LAN packets until the server is closed. A magic packet for
+// the display's mac address brings it out of deep standby, if Wake-on-LAN is enabled.
+func (s *Server) listenWakeOnLAN() {
+	buf := make([]byte, 1024)
+	for {
+		n, _, err := s.wol.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		if s.state.WakeOnLAN && isMagicPacket(buf[:n], s.state.Info.MACAddress) {
+			s.state.DeepStandby = false
+		}
+		s.mu.Unlock()
+	}
+}
+
+func isMagicPacket(packet []byte, mac string) bool {
+	hwAddr, err := net.ParseMAC(mac)
+	if err != nil || len(packet) < 6+16*len(hwAddr) {
+		return false
+	}
+
+	if !bytes.Equal(packet[:6], []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}) {
+		return false
+	}
+
+	for i := 0; i < 16; i++ {
+		start := 6 + i*len(hwAddr)
+		if !bytes.Equal(packet[start:start+len(hwAddr)], hwAddr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func getWolMode(s *Server, req request) ([]interface{}, rpcError) {
+	return []interface{}{
+		map[string]interface{}{
+			"enabled": s.state.WakeOnLAN,
+		},
+	}, 0
+}
+
+func setWolMode(s *Server, req request) ([]interface{}, rpcError) {
+	var params struct {
+		Enabled *bool `json:"enabled"`
+	}
+
+	if code := decodeParams(req, &params); code != 0 {
+		return nil, code
+	}
+
+	if params.Enabled == nil {
+		return nil, CodeIllegalArgument
+	}
+
+	s.state.WakeOnLAN = *params.Enabled
+	return nil, 0
+}
+
+func getNetworkSettings(s *Server, req request) ([]interface{}, rpcError) {
+	var params struct {
+		Netif string `json:"netif"`
+	}
+
+	if code := decodeParams(req, &params); code != 0 {
+		return nil, code
+	}
+
+	if params.Netif != "" && params.Netif != "eth0" {
+		return nil, CodeIllegalArgument
+	}
+
+	ifaces := []map[string]interface{}{
+		{
+			"netif":    "eth0",
+			"hwAddr":   s.state.Info.MACAddress,
+			"ipAddrV4": "127.0.0.1",
+			"ipAddrV6": "",
+			"netmask":  "255.0.0.0",
+			"gateway":  "127.0.0.1",
+			"dns":      []string{},
+		},
+	}
+
+	return []interface{}{ifaces}, 0
+}
@@ -0,0 +1,127 @@
+package bravia
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultConfirmInterval is how often setters poll the display when ConfirmPolicy.Interval isn't set.
+	DefaultConfirmInterval = 500 * time.Millisecond
+
+	// DefaultConfirmWait is the longest setters wait for confirmation when ConfirmPolicy.MaxWait isn't set.
+	DefaultConfirmWait = 30 * time.Second
+)
+
+// ConfirmPolicy controls how setters (ie, SetPower or SetMute) wait for the display to report the state they set.
+type ConfirmPolicy struct {
+	// Disabled makes setters return as soon as the display accepts the request.
+	Disabled bool
+
+	// Interval is how often the display is polled. Defaults to DefaultConfirmInterval.
+	Interval time.Duration
+
+	// MaxWait is the longest a setter waits for confirmation, even if its context has a
+	// later deadline or none at all. Defaults to DefaultConfirmWait.
+	MaxWait time.Duration
+
+	// Matches is how many polls in a row must report the new state. Defaults to 1.
+	Matches int
+}
+
+// ErrNotConfirmed is returned by setters when the display accepted a request, but didn't report
+// the new state before the confirmation policy's wait (or the context) ran out. SetPower also
+// returns it when a display being woken with Wake-on-LAN doesn't become reachable in time.
+type ErrNotConfirmed struct {
+	// Setting is what was being set, ie "power" or "input".
+	Setting string
+
+	// Want is the state that was set, and Last is the state the display last reported.
+	// Last is nil if the display was never successfully polled.
+	Want interface{}
+	Last interface{}
+
+	// Err is why confirmation stopped: the context's error, or the error from polling the display.
+	Err error
+}
+
+func (e *ErrNotConfirmed) Error() string {
+	if e.Last == nil {
+		return fmt.Sprintf("unable to confirm %s set to %v: %v", e.Setting, e.Want, e.Err)
+	}
+
+	return fmt.Sprintf("unable to confirm %s set to %v (last was %v): %v", e.Setting, e.Want, e.Last, e.Err)
+}
+
+func (e *ErrNotConfirmed) Unwrap() error {
+	return e.Err
+}
+
+// maxWait returns MaxWait, or DefaultConfirmWait if it isn't set.
+func (p ConfirmPolicy) maxWait() time.Duration {
+	if p.MaxWait <= 0 {
+		return DefaultConfirmWait
+	}
+
+	return p.MaxWait
+}
+
+// confirm polls get until it reports want, following the display's confirmation policy.
+func (d *Display) confirm(ctx context.Context, setting string, want interface{}, get func(context.Context) (interface{}, error)) error {
+	policy := d.Confirm
+	if policy.Disabled {
+		return nil
+	}
+
+	if policy.Interval <= 0 {
+		policy.Interval = DefaultConfirmInterval
+	}
+
+	if policy.Matches < 1 {
+		policy.Matches = 1
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, policy.maxWait())
+	defer cancel()
+
+	ticker := time.NewTicker(policy.Interval)
+	defer ticker.Stop()
+
+	notConfirmed := &ErrNotConfirmed{
+		Setting: setting,
+		Want:    want,
+	}
+
+	matches := 0
+	for {
+		select {
+		case <-ticker.C:
+			last, err := get(ctx)
+			switch {
+			case err != nil && ctx.Err() != nil:
+				// the poll was cut off by the wait running out
+				notConfirmed.Err = ctx.Err()
+				return notConfirmed
+			case err != nil:
+				notConfirmed.Err = err
+				return notConfirmed
+			}
+
+			notConfirmed.Last = last
+
+			if last != want {
+				matches = 0
+				continue
+			}
+
+			matches++
+			if matches >= policy.Matches {
+				return nil
+			}
+		case <-ctx.Done():
+			notConfirmed.Err = ctx.Err()
+			return notConfirmed
+		}
+	}
+}
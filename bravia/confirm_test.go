@@ -0,0 +1,91 @@
+package bravia
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/byuoitav/sony/bravia/braviatest"
+	"github.com/matryer/is"
+	"go.uber.org/zap/zaptest"
+)
+
+func newConfirmDisplay(t *testing.T, policy ConfirmPolicy) (*Display, *braviatest.Server) {
+	srv := braviatest.NewServer("")
+	t.Cleanup(srv.Close)
+
+	return &Display{
+		Address:      srv.Address(),
+		Log:          zaptest.NewLogger(t),
+		RequestDelay: 10 * time.Millisecond,
+		Confirm:      policy,
+	}, srv
+}
+
+func TestConfirmDisabled(t *testing.T) {
+	is := is.New(t)
+
+	d, srv := newConfirmDisplay(t, ConfirmPolicy{Disabled: true})
+	srv.SetIgnored("setPowerStatus", true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	is.NoErr(d.SetPower(ctx, true))
+	is.True(!srv.State().Power)
+}
+
+func TestNotConfirmed(t *testing.T) {
+	is := is.New(t)
+
+	d, srv := newConfirmDisplay(t, ConfirmPolicy{Interval: 20 * time.Millisecond, MaxWait: 200 * time.Millisecond})
+	srv.SetIgnored("setPowerStatus", true)
+
+	// the policy's wait applies even without a deadline
+	err := d.SetPower(context.Background(), true)
+
+	var notConfirmed *ErrNotConfirmed
+	is.True(errors.As(err, &notConfirmed))
+	is.Equal(notConfirmed.Setting, "power")
+	is.Equal(notConfirmed.Want, true)
+	is.Equal(notConfirmed.Last, false)
+	is.True(errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestNotConfirmedPollError(t *testing.T) {
+	is := is.New(t)
+
+	d, srv := newConfirmDisplay(t, ConfirmPolicy{Interval: 20 * time.Millisecond})
+	srv.SetError("getPowerSavingMode", braviatest.CodeIllegalState)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := d.SetBlank(ctx, true)
+
+	var notConfirmed *ErrNotConfirmed
+	is.True(errors.As(err, &notConfirmed))
+	is.Equal(notConfirmed.Last, nil)
+	is.True(errors.Is(err, ErrIllegalState))
+}
+
+func TestConfirmMatches(t *testing.T) {
+	is := is.New(t)
+
+	d, srv := newConfirmDisplay(t, ConfirmPolicy{Interval: 20 * time.Millisecond, Matches: 3})
+	srv.SetState(func(s *braviatest.State) {
+		s.Power = true
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	is.NoErr(d.SetAudioVideoInput(ctx, "", "hdmi?port=2"))
+	is.True(time.Since(start) >= 60*time.Millisecond)
+	is.Equal(srv.State().Input, "extInput:hdmi?port=2")
+
+	is.NoErr(d.SetMute(ctx, "speaker", true))
+	is.True(srv.State().Volumes[0].Mute)
+}
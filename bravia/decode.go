@@ -0,0 +1,113 @@
+package bravia
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// decodeResult decodes the result array of a response into results, in order.
+// Every field in results without omitempty in its json tag must be present in
+// the response, and errors say which field was missing or had the wrong type.
+func decodeResult(method string, raw json.RawMessage, results ...interface{}) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	var list []json.RawMessage
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return fmt.Errorf("%s: result is not an array: %s", method, raw)
+	}
+
+	if len(list) < len(results) {
+		return fmt.Errorf("%s: result has %v elements, expected %v: %s", method, len(list), len(results), raw)
+	}
+
+	for i, v := range results {
+		path := fmt.Sprintf("result[%d]", i)
+
+		if err := checkFields(list[i], reflect.TypeOf(v), path); err != nil {
+			return fmt.Errorf("%s: %w", method, err)
+		}
+
+		if err := json.Unmarshal(list[i], v); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				return fmt.Errorf("%s: %s.%s: got %s, expected %s", method, path, typeErr.Field, typeErr.Value, typeErr.Type)
+			}
+
+			return fmt.Errorf("%s: %s: %w", method, path, err)
+		}
+	}
+
+	return nil
+}
+
+// checkFields walks raw alongside t, returning an error naming the first required field that is missing.
+func checkFields(raw json.RawMessage, t reflect.Type, path string) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var m map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return fmt.Errorf("%s: got %s, expected object", path, raw)
+		}
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+
+			name, opts := field.Name, ""
+			if tag, ok := field.Tag.Lookup("json"); ok {
+				parts := strings.SplitN(tag, ",", 2)
+				if parts[0] == "-" {
+					continue
+				}
+
+				if parts[0] != "" {
+					name = parts[0]
+				}
+
+				if len(parts) > 1 {
+					opts = parts[1]
+				}
+			}
+
+			val, ok := m[name]
+			switch {
+			case !ok && strings.Contains(opts, "omitempty"):
+				continue
+			case !ok:
+				return fmt.Errorf("%s: missing field %q", path, name)
+			}
+
+			if err := checkFields(val, field.Type, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+
+		var list []json.RawMessage
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return fmt.Errorf("%s: got %s, expected array", path, raw)
+		}
+
+		for i := range list {
+			if err := checkFields(list[i], t.Elem(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package bravia
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestDecodeResult(t *testing.T) {
+	is := is.New(t)
+
+	var infos []volumeInformation
+	raw := json.RawMessage(`[[{"target":"speaker","volume":20,"mute":false,"maxVolume":100,"minVolume":0}]]`)
+	is.NoErr(decodeResult("getVolumeInformation", raw, &infos))
+	is.Equal(len(infos), 1)
+	is.Equal(infos[0].Volume, 20)
+
+	raw = json.RawMessage(`[[{"target":"speaker","volume":20,"mute":false,"minVolume":0}]]`)
+	err := decodeResult("getVolumeInformation", raw, &infos)
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), `result[0][0]: missing field "maxVolume"`))
+
+	raw = json.RawMessage(`[[{"target":"speaker","volume":"20","mute":false,"maxVolume":100,"minVolume":0}]]`)
+	err = decodeResult("getVolumeInformation", raw, &infos)
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "volume"))
+	is.True(strings.Contains(err.Error(), "expected int"))
+
+	var info playingContentInfo
+	raw = json.RawMessage(`[{"uri":"extInput:hdmi?port=1"}]`)
+	is.NoErr(decodeResult("getPlayingContentInfo", raw, &info))
+	is.Equal(info.URI, "extInput:hdmi?port=1")
+
+	raw = json.RawMessage(`[]`)
+	is.True(decodeResult("getPlayingContentInfo", raw, &info) != nil)
+}
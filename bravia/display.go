@@ -25,14 +25,80 @@ type Display struct {
 
 	RequestDelay time.Duration
 
+	// HTTPClient is used to make requests to the display. If nil, http.DefaultClient is used.
+	// To use a custom http.RoundTripper, set it as the client's Transport.
+	HTTPClient *http.Client
+
+	// Scheme is the scheme used to reach the display, either "http" (the default) or "https".
+	Scheme string
+
+	// Port is the port used to reach the display, if Address doesn't include one.
+	// If both are empty, the default port for Scheme is used.
+	Port int
+
+	// Timeout limits how long a single request can take when its context has no deadline.
+	// Defaults to DefaultTimeout.
+	Timeout time.Duration
+
+	// MACAddress is the mac address Wake-on-LAN packets are sent to when the display can't be
+	// reached to turn it on. If empty, it is learned from the display the first time it is turned on or off.
+	MACAddress string
+
+	// WakeOnLANAddress is the address Wake-on-LAN packets are sent to. Defaults to "255.255.255.255:9".
+	WakeOnLANAddress string
+
+	// Confirm controls how setters wait for the display to report the state they set.
+	Confirm ConfirmPolicy
+
+	// NormalizeVolume scales volumes into 0-100 for Volumes, SetVolume, and VolumeEvents,
+	// using the minimum and maximum level each target reports.
+	NormalizeVolume bool
+
+	// MaxVolume is the highest level volumes can be set to, in the display's own levels.
+	// When NormalizeVolume is true, 100 is scaled to MaxVolume instead of the target's maximum.
+	// Zero means no ceiling.
+	MaxVolume int
+
 	once    sync.Once
 	limiter *rate.Limiter
+
+	remoteMu    sync.Mutex
+	remoteCodes map[string]string
+
+	volMu     sync.Mutex
+	volRanges map[string]volumeInformation
+
+	capsMu      sync.Mutex
+	caps        Capabilities
+	capsErr     error
+	capsOffErr  error
+	capsRetryAt time.Time
+	capsFetch   *capabilitiesFetch
+
+	wakeMu     sync.Mutex
+	wakeTried  bool
+	wakeMAC    string
+	wolEnabled *bool
 }
 
 func (d *Display) init() {
 	d.limiter = rate.NewLimiter(rate.Every(d.RequestDelay), 1)
 }
 
+// wait blocks until the rate limit allows another request.
+func (d *Display) wait(ctx context.Context) error {
+	if err := d.limiter.Wait(ctx); err != nil {
+		// the limiter gives up early if waiting would pass ctx's deadline
+		if ctx.Err() == nil {
+			return context.DeadlineExceeded
+		}
+
+		return ctx.Err()
+	}
+
+	return nil
+}
+
 type request struct {
 	Method  string                   `json:"method"`
 	Version string                   `json:"version"`
@@ -40,41 +106,60 @@ type request struct {
 }
 
 type response struct {
-	ID     int           `json:"id"`
-	Result []interface{} `json:"result"`
-	Error  []interface{} `json:"error"`
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  []interface{}   `json:"error"`
 }
 
-func (r *response) BuildError() error {
-	if len(r.Error) == 0 {
+// httpError returns the error for an http status code, or nil if it is http.StatusOK.
+func httpError(status int) error {
+	switch status {
+	case http.StatusOK:
 		return nil
+	case http.StatusForbidden, http.StatusNotFound:
+		return newError(status)
+	default:
+		return fmt.Errorf("http code %v", status)
 	}
+}
 
-	err := &Error{
-		code:   -1,
-		reason: fmt.Sprintf("unable to parse error %+v", r.Error),
+func (r *response) BuildError() error {
+	if len(r.Error) == 0 {
+		return nil
 	}
 
 	code, ok := r.Error[0].(float64)
 	if !ok {
-		return err
+		return &Error{
+			code:   -1,
+			reason: fmt.Sprintf("unable to parse error %+v", r.Error),
+		}
 	}
-	err.code = int(code)
 
+	err := newError(int(code))
 	if len(r.Error) < 2 {
 		return err
 	}
 
-	reason, ok := r.Error[1].(string)
-	if !ok {
-		return err
+	if reason, ok := r.Error[1].(string); ok && reason != "" {
+		err.reason = reason
 	}
-	err.reason = reason
 
 	return err
 }
 
-func (d *Display) doRequest(ctx context.Context, service string, req request) ([]interface{}, error) {
+// doRequest calls req.Method on service, decoding each element of the result array into results, in order.
+// It returns ErrUnsupported without calling the display if the display doesn't support req.Version of req.Method.
+func (d *Display) doRequest(ctx context.Context, service string, req request, results ...interface{}) error {
+	if _, err := d.negotiate(ctx, service, req.Method, req.Version); err != nil {
+		return err
+	}
+
+	return d.send(ctx, service, req, results...)
+}
+
+// send calls req.Method on service without checking if the display supports it.
+func (d *Display) send(ctx context.Context, service string, req request, results ...interface{}) error {
 	d.once.Do(d.init)
 
 	wrapped := struct {
@@ -88,48 +173,56 @@ func (d *Display) doRequest(ctx context.Context, service string, req request) ([
 	// add an id to the request
 	body, err := json.Marshal(wrapped)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	url := fmt.Sprintf("http://%s/sony/%s", d.Address, service)
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url("/sony/"+service, false), bytes.NewBuffer(body))
 	if err != nil {
-		return nil, fmt.Errorf("unable to build request: %w", err)
+		return fmt.Errorf("unable to build request: %w", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("X-Auth-PSK", d.PreSharedKey)
 
-	if err := d.limiter.Wait(ctx); err != nil {
-		return nil, fmt.Errorf("unable to wait for ratelimit: %w", err)
+	if err := d.wait(ctx); err != nil {
+		return fmt.Errorf("unable to wait for ratelimit: %w", err)
 	}
 
 	d.Log.Debug("Doing request", zap.String("url", httpReq.URL.String()), zap.ByteString("body", body))
 
-	resp, err := http.DefaultClient.Do(httpReq)
+	resp, err := d.client().Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("unable to do request: %w", err)
+		return fmt.Errorf("unable to do request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	// the body of these isn't always a json-rpc response
+	switch resp.StatusCode {
+	case http.StatusForbidden, http.StatusNotFound:
+		return httpError(resp.StatusCode)
+	}
+
 	var response response
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("unable to decode response: %w", err)
+		return fmt.Errorf("unable to decode response: %w", err)
 	}
 
-	d.Log.Debug("Response", zap.Any("resp", response))
+	d.Log.Debug("Response", zap.Int("id", response.ID), zap.ByteString("result", response.Result), zap.Any("error", response.Error))
 
 	if wrapped.ID != response.ID {
-		return nil, fmt.Errorf("incorrect response id")
+		return fmt.Errorf("incorrect response id")
 	}
 
 	if err := response.BuildError(); err != nil {
-		return nil, err
+		return err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("http code %v", resp.StatusCode)
+	if err := httpError(resp.StatusCode); err != nil {
+		return err
 	}
 
-	return response.Result, nil
+	return decodeResult(req.Method, response.Result, results...)
 }
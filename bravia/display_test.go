@@ -4,16 +4,36 @@ import (
 	"os"
 	"testing"
 	"time"
+
+	"github.com/byuoitav/sony/bravia/braviatest"
 )
 
-var disp *Display
+var (
+	disp *Display
+
+	// srv is the emulated display the tests run against, or nil if they are
+	// being run against a real display
+	srv *braviatest.Server
+)
 
+// TestMain runs the tests against an emulated display, unless BRAVIA_ADDRESS
+// is set, in which case they are run against that display.
 func TestMain(m *testing.M) {
 	disp = &Display{
-		Address:      "ITB-2033-D1.byu.edu",
+		Address:      os.Getenv("BRAVIA_ADDRESS"),
 		PreSharedKey: os.Getenv("BRAVIA_PSK"),
 		RequestDelay: 300 * time.Millisecond,
 	}
 
+	if disp.Address == "" {
+		srv = braviatest.NewServer(disp.PreSharedKey)
+		disp.Address = srv.Address()
+		disp.RequestDelay = 10 * time.Millisecond
+
+		code := m.Run()
+		srv.Close()
+		os.Exit(code)
+	}
+
 	os.Exit(m.Run())
 }
@@ -1,16 +1,106 @@
 package bravia
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
+// Error codes returned by the display, documented at
+// https://pro-bravia.sony.net/develop/integrate/rest-api/spec/errorcode-list/index.html.
 const (
-	_displayOff = 40005
+	CodeAny                    = 1
+	CodeTimeout                = 2
+	CodeIllegalArgument        = 3
+	CodeIllegalRequest         = 5
+	CodeIllegalState           = 7
+	CodeNoSuchMethod           = 12
+	CodeUnsupportedVersion     = 14
+	CodeUnsupportedOperation   = 15
+	CodeUnauthorized           = 401
+	CodeForbidden              = 403
+	CodeNotFound               = 404
+	CodeNotImplemented         = 501
+	CodeRequestRetry           = 40000
+	CodeClientOverMaximum      = 40001
+	CodeEncryptionFailed       = 40002
+	CodeRequestDuplicated      = 40003
+	CodeMultipleSettingsFailed = 40004
+	CodeDisplayOff             = 40005
+	CodeIllegalTarget          = 40800
+	CodeUnsupportedTarget      = 40801
 )
 
+var reasons = map[int]string{
+	CodeAny:                    "Any",
+	CodeTimeout:                "Timeout",
+	CodeIllegalArgument:        "Illegal Argument",
+	CodeIllegalRequest:         "Illegal Request",
+	CodeIllegalState:           "Illegal State",
+	CodeNoSuchMethod:           "No Such Method",
+	CodeUnsupportedVersion:     "Unsupported Version",
+	CodeUnsupportedOperation:   "Unsupported Operation",
+	CodeUnauthorized:           "Unauthorized",
+	CodeForbidden:              "Forbidden",
+	CodeNotFound:               "Not Found",
+	CodeNotImplemented:         "Not Implemented",
+	CodeRequestRetry:           "Request Retry",
+	CodeClientOverMaximum:      "Client Over Maximum",
+	CodeEncryptionFailed:       "Encryption Failed",
+	CodeRequestDuplicated:      "Request Duplicated",
+	CodeMultipleSettingsFailed: "Multiple Settings Failed",
+	CodeDisplayOff:             "Display Is Turned Off",
+	CodeIllegalTarget:          "Illegal Target",
+	CodeUnsupportedTarget:      "Unsupported Target",
+}
+
+// ErrUnsupported is returned when the display doesn't support any version of a method that this package can use.
+var ErrUnsupported = errors.New("unsupported api")
+
+// Errors returned by the display, for use with errors.Is. An *Error matches
+// one of these if it has the same code, regardless of its reason.
+var (
+	ErrIllegalArgument    = newError(CodeIllegalArgument)
+	ErrIllegalRequest     = newError(CodeIllegalRequest)
+	ErrIllegalState       = newError(CodeIllegalState)
+	ErrNoSuchMethod       = newError(CodeNoSuchMethod)
+	ErrUnsupportedVersion = newError(CodeUnsupportedVersion)
+	ErrUnauthorized       = newError(CodeUnauthorized)
+	ErrForbidden          = newError(CodeForbidden)
+	ErrNotFound           = newError(CodeNotFound)
+	ErrDisplayOff         = newError(CodeDisplayOff)
+	ErrIllegalTarget      = newError(CodeIllegalTarget)
+)
+
+// Error is an error returned by the display. Forbidden (ie, a bad pre-shared key) and
+// not found http responses are also returned as an Error, with the http status as the code.
 type Error struct {
 	code   int
 	reason string
 }
 
+// newError returns an Error with code and its documented reason.
+func newError(code int) *Error {
+	reason, ok := reasons[code]
+	if !ok {
+		reason = "Unknown Error"
+	}
+
+	return &Error{
+		code:   code,
+		reason: reason,
+	}
+}
+
+// Code returns the error code, or -1 if the error couldn't be parsed.
+func (e *Error) Code() int {
+	return e.code
+}
+
+// Reason returns the reason the display gave for the error.
+func (e *Error) Reason() string {
+	return e.reason
+}
+
 func (e *Error) Error() string {
 	if e.code < 0 {
 		return e.reason
@@ -18,3 +108,9 @@ func (e *Error) Error() string {
 
 	return fmt.Sprintf("%v: %v", e.code, e.reason)
 }
+
+// Is returns true if target is an *Error with the same code as e.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.code == e.code
+}
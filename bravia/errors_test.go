@@ -0,0 +1,68 @@
+package bravia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/byuoitav/sony/bravia/braviatest"
+	"github.com/matryer/is"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestErrorIs(t *testing.T) {
+	is := is.New(t)
+
+	err := fmt.Errorf("wrapped: %w", &Error{code: CodeDisplayOff, reason: "Display Is Turned off"})
+	is.True(errors.Is(err, ErrDisplayOff))
+	is.True(!errors.Is(err, ErrIllegalArgument))
+
+	var bErr *Error
+	is.True(errors.As(err, &bErr))
+	is.Equal(bErr.Code(), CodeDisplayOff)
+	is.Equal(bErr.Reason(), "Display Is Turned off")
+
+	is.Equal(newError(CodeForbidden).Error(), "403: Forbidden")
+	is.Equal(newError(12345).Reason(), "Unknown Error")
+}
+
+func TestDisplayErrors(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv := braviatest.NewServer("secret")
+	defer srv.Close()
+
+	d := &Display{
+		Address:      srv.Address(),
+		PreSharedKey: "wrong",
+		Log:          zaptest.NewLogger(t),
+		RequestDelay: 10 * time.Millisecond,
+	}
+
+	_, err := d.Power(ctx)
+	is.True(errors.Is(err, ErrForbidden))
+
+	is.True(errors.Is(d.sendIRCC(ctx, "AAAAAQAAAAEAAAAVAw=="), ErrForbidden))
+
+	d.PreSharedKey = "secret"
+
+	err = d.send(ctx, "notAService", request{Version: "1.0", Method: "getPowerStatus", Params: []map[string]interface{}{}})
+	is.True(errors.Is(err, ErrNotFound))
+
+	srv.SetError("getPowerStatus", braviatest.CodeIllegalState)
+	_, err = d.Power(ctx)
+	is.True(errors.Is(err, ErrIllegalState))
+	srv.ClearError("getPowerStatus")
+
+	// the display is in standby
+	_, err = d.getVolumeInformation(ctx)
+	is.True(errors.Is(err, ErrDisplayOff))
+
+	err = d.send(ctx, "system", request{Version: "9.9", Method: "getPowerStatus", Params: []map[string]interface{}{}})
+	is.True(errors.Is(err, ErrUnsupportedVersion))
+}
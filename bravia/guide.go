@@ -2,10 +2,201 @@ package bravia
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
-func (d *Display) getSupportedAPIInfo(ctx context.Context) error {
+// how long a display that was off isn't asked for its capabilities again
+const _capabilitiesRetry = 10 * time.Second
+
+// Capabilities are the APIs a display supports, mapped from service to method to the supported versions.
+type Capabilities map[string]map[string][]string
+
+// Versions returns the versions of method on service that the display supports, highest first.
+func (c Capabilities) Versions(service, method string) []string {
+	versions := append([]string(nil), c[service][method]...)
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i], versions[j]) > 0
+	})
+
+	return versions
+}
+
+// Supports returns true if the display supports the given version of method on service.
+func (c Capabilities) Supports(service, method, version string) bool {
+	for _, v := range c[service][method] {
+		if v == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+type serviceAPIs struct {
+	Service string   `json:"service"`
+	APIs    []apiSet `json:"apis"`
+}
+
+type apiSet struct {
+	Name     string       `json:"name"`
+	Versions []apiVersion `json:"versions"`
+}
+
+type apiVersion struct {
+	Version string `json:"version"`
+}
+
+// Capabilities returns the APIs the display supports. They are fetched once and then cached for the life of the Display,
+// as is the display's error if it can't report them. If the display is off, it isn't asked again for a few seconds.
+func (d *Display) Capabilities(ctx context.Context) (Capabilities, error) {
+	caps, err := d.capabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return caps.copy(), nil
+}
+
+// capabilitiesFetch is a request for the display's capabilities that other callers can wait for.
+type capabilitiesFetch struct {
+	done chan struct{}
+
+	// set before done is closed
+	caps     Capabilities
+	err      error
+	canceled bool
+}
+
+// capabilities returns the cached capabilities, which must not be modified. Only one caller asks the display
+// at a time; the others share its result, or give up when their own ctx is done.
+func (d *Display) capabilities(ctx context.Context) (Capabilities, error) {
+	for {
+		d.capsMu.Lock()
+		switch {
+		case d.caps != nil:
+			d.capsMu.Unlock()
+			return d.caps, nil
+		case d.capsErr != nil:
+			d.capsMu.Unlock()
+			return nil, d.capsErr
+		case d.capsOffErr != nil && time.Now().Before(d.capsRetryAt):
+			err := d.capsOffErr
+			d.capsMu.Unlock()
+			return nil, err
+		}
+
+		fetch := d.capsFetch
+		if fetch == nil {
+			break
+		}
+		d.capsMu.Unlock()
+
+		select {
+		case <-fetch.done:
+			// if the caller fetching gave up, try again with this ctx
+			if !fetch.canceled {
+				return fetch.caps, fetch.err
+			}
+		case <-ctx.Done():
+			return nil, fmt.Errorf("unable to wait for capabilities: %w", ctx.Err())
+		}
+	}
+
+	fetch := &capabilitiesFetch{done: make(chan struct{})}
+	d.capsFetch = fetch
+	d.capsMu.Unlock()
+
+	fetch.caps, fetch.err = d.fetchCapabilities(ctx)
+	fetch.canceled = fetch.err != nil && ctx.Err() != nil
+
+	d.capsMu.Lock()
+	defer d.capsMu.Unlock()
+
+	d.capsFetch = nil
+	close(fetch.done)
+
+	var bErr *Error
+	switch {
+	case fetch.err == nil:
+		d.caps = fetch.caps
+	case errors.Is(fetch.err, ErrDisplayOff):
+		// the display can't answer until it's turned on
+		d.capsOffErr, d.capsRetryAt = fetch.err, time.Now().Add(_capabilitiesRetry)
+	case errors.As(fetch.err, &bErr):
+		// the display answered, so asking again won't change anything
+		d.capsErr = fetch.err
+	}
+
+	return fetch.caps, fetch.err
+}
+
+func (d *Display) fetchCapabilities(ctx context.Context) (Capabilities, error) {
+	services, err := d.getSupportedAPIInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	caps := make(Capabilities, len(services))
+	for _, service := range services {
+		methods := make(map[string][]string, len(service.APIs))
+		for _, api := range service.APIs {
+			for _, version := range api.Versions {
+				methods[api.Name] = append(methods[api.Name], version.Version)
+			}
+		}
+
+		caps[service.Service] = methods
+	}
+
+	return caps, nil
+}
+
+func (c Capabilities) copy() Capabilities {
+	cp := make(Capabilities, len(c))
+	for service, methods := range c {
+		cp[service] = make(map[string][]string, len(methods))
+		for method, versions := range methods {
+			cp[service][method] = append([]string(nil), versions...)
+		}
+	}
+
+	return cp
+}
+
+// negotiate returns the highest of versions of method on service that the display supports,
+// or ErrUnsupported if it doesn't support any of them. If the display can't report what
+// it supports, the lowest of versions is returned.
+func (d *Display) negotiate(ctx context.Context, service, method string, versions ...string) (string, error) {
+	sorted := append([]string(nil), versions...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return compareVersions(sorted[i], sorted[j]) > 0
+	})
+
+	caps, err := d.capabilities(ctx)
+	if err != nil {
+		var bErr *Error
+		if errors.As(err, &bErr) && len(sorted) > 0 {
+			return sorted[len(sorted)-1], nil
+		}
+
+		return "", fmt.Errorf("unable to get capabilities: %w", err)
+	}
+
+	for _, version := range sorted {
+		if caps.Supports(service, method, version) {
+			return version, nil
+		}
+	}
+
+	return "", fmt.Errorf("%s.%s %v: %w", service, method, versions, ErrUnsupported)
+}
+
+func (d *Display) getSupportedAPIInfo(ctx context.Context) ([]serviceAPIs, error) {
 	req := request{
 		Version: "1.0",
 		Method:  "getSupportedApiInfo",
@@ -16,14 +207,33 @@ func (d *Display) getSupportedAPIInfo(ctx context.Context) error {
 		},
 	}
 
-	res, err := d.doRequest(ctx, "guide", req)
-	switch {
-	case err != nil:
-		return err
-	case len(res) < 1:
-		return fmt.Errorf("unexpected response: %+v", res)
+	var services []serviceAPIs
+	if err := d.send(ctx, "guide", req, &services); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return services, nil
 }
 
+// compareVersions returns a positive number if a is a higher version than b,
+// a negative number if it is lower, and 0 if they are the same.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+
+		if x != y {
+			return x - y
+		}
+	}
+
+	return 0
+}
@@ -2,9 +2,13 @@ package bravia
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/byuoitav/sony/bravia/braviatest"
 	"github.com/matryer/is"
 	"go.uber.org/zap/zaptest"
 )
@@ -16,6 +20,183 @@ func TestSupportedAPIInfo(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	err := disp.getSupportedAPIInfo(ctx)
+	services, err := disp.getSupportedAPIInfo(ctx)
 	is.NoErr(err)
+	is.True(len(services) > 0)
+}
+
+func TestCapabilities(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	caps, err := disp.Capabilities(ctx)
+	is.NoErr(err)
+	is.True(caps.Supports("system", "getPowerStatus", "1.0"))
+	is.True(len(caps.Versions("audio", "setAudioVolume")) > 0)
+	is.True(!caps.Supports("system", "notAMethod", "1.0"))
+
+	// changing the returned capabilities doesn't change the cache
+	delete(caps, "system")
+
+	caps, err = disp.Capabilities(ctx)
+	is.NoErr(err)
+	is.True(caps.Supports("system", "getPowerStatus", "1.0"))
+}
+
+func TestCapabilitiesUnknown(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s := braviatest.NewServer("")
+	defer s.Close()
+
+	// old firmware without getSupportedApiInfo
+	s.SetError("getSupportedApiInfo", braviatest.CodeNoSuchMethod)
+
+	transport := &countingTransport{counts: make(map[string]int)}
+	d := &Display{
+		Address:    s.Address(),
+		Log:        zaptest.NewLogger(t),
+		HTTPClient: &http.Client{Transport: transport},
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := d.Power(ctx)
+		is.NoErr(err)
+	}
+
+	// only asked once, even though it failed
+	is.Equal(transport.count("getSupportedApiInfo"), 1)
+	is.Equal(transport.count("getPowerStatus"), 3)
+}
+
+func TestCapabilitiesDisplayOff(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s := braviatest.NewServer("")
+	defer s.Close()
+
+	s.SetError("getSupportedApiInfo", braviatest.CodeDisplayOff)
+
+	transport := &countingTransport{counts: make(map[string]int)}
+	d := &Display{
+		Address:    s.Address(),
+		Log:        zaptest.NewLogger(t),
+		HTTPClient: &http.Client{Transport: transport},
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := d.Power(ctx)
+		is.NoErr(err)
+	}
+
+	is.Equal(transport.count("getSupportedApiInfo"), 1)
+}
+
+// blockingTransport blocks requests to the guide service until release is closed.
+type blockingTransport struct {
+	release chan struct{}
+}
+
+func (b *blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if strings.HasSuffix(req.URL.Path, "/guide") {
+		select {
+		case <-b.release:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestCapabilitiesWait(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s := braviatest.NewServer("")
+	defer s.Close()
+
+	transport := &blockingTransport{release: make(chan struct{})}
+	d := &Display{
+		Address:    s.Address(),
+		Log:        zaptest.NewLogger(t),
+		HTTPClient: &http.Client{Transport: transport},
+	}
+
+	fetched := make(chan error)
+	go func() {
+		_, err := d.Capabilities(ctx)
+		fetched <- err
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	// waiting for the first caller honors this caller's deadline
+	shortCtx, shortCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer shortCancel()
+
+	start := time.Now()
+	_, err := d.Capabilities(shortCtx)
+	is.True(errors.Is(err, context.DeadlineExceeded))
+	is.True(time.Since(start) < time.Second)
+
+	close(transport.release)
+	is.NoErr(<-fetched)
+
+	caps, err := d.Capabilities(ctx)
+	is.NoErr(err)
+	is.True(caps.Supports("system", "getPowerStatus", "1.0"))
+}
+
+func TestVersionNegotiation(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s := braviatest.NewServer("")
+	defer s.Close()
+
+	s.SetVersions("audio", "setAudioVolume", "1.0")
+	s.SetVersions("avContent", "getCurrentExternalInputsStatus", "1.0")
+	s.SetVersions("avContent", "setPlayContent")
+
+	d := &Display{
+		Address: s.Address(),
+		Log:     zaptest.NewLogger(t),
+	}
+
+	is.NoErr(d.SetPower(ctx, true))
+
+	// falls back to 1.0, without the ui param
+	is.NoErr(d.SetVolume(ctx, "speaker", 30))
+	is.Equal(s.State().Volumes[0].Volume, 30)
+
+	_, err := d.getCurrentExternalInputsStatus(ctx)
+	is.NoErr(err)
+
+	err = d.SetAudioVideoInput(ctx, "", "hdmi?port=2")
+	is.True(errors.Is(err, ErrUnsupported))
+
+	is.True(errors.Is(d.Healthy(ctx), ErrUnsupported))
+}
+
+func TestCompareVersions(t *testing.T) {
+	is := is.New(t)
+
+	is.True(compareVersions("1.2", "1.0") > 0)
+	is.True(compareVersions("1.0", "1.10") < 0)
+	is.True(compareVersions("1.0", "1.0") == 0)
+	is.True(compareVersions("2.0", "1.9") > 0)
 }
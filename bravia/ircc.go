@@ -0,0 +1,136 @@
+package bravia
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+const irccEnvelope = `<?xml version="1.0"?>
+<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">
+	<s:Body>
+		<u:X_SendIRCC xmlns:u="urn:schemas-sony-com:service:IRCC:1">
+			<IRCCCode>%s</IRCCCode>
+		</u:X_SendIRCC>
+	</s:Body>
+</s:Envelope>`
+
+// RemoteKeys returns the names of the remote control keys the display supports, mapped to their IRCC codes.
+func (d *Display) RemoteKeys(ctx context.Context) (map[string]string, error) {
+	codes, err := d.remoteKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]string, len(codes))
+	for name, code := range codes {
+		keys[name] = code
+	}
+
+	return keys, nil
+}
+
+// remoteKeys returns the cached remote control keys, which must not be modified.
+func (d *Display) remoteKeys(ctx context.Context) (map[string]string, error) {
+	d.remoteMu.Lock()
+	defer d.remoteMu.Unlock()
+
+	if d.remoteCodes != nil {
+		return d.remoteCodes, nil
+	}
+
+	codes, err := d.getRemoteControllerInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	d.remoteCodes = codes
+	return codes, nil
+}
+
+// SendRemoteKey presses the remote control key with the given name (ie, "VolumeUp", "Home", or "Netflix")
+// through the display's IRCC-IP endpoint. Names are resolved using the table from RemoteKeys.
+func (d *Display) SendRemoteKey(ctx context.Context, name string) error {
+	codes, err := d.remoteKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get remote keys: %w", err)
+	}
+
+	code, ok := codes[name]
+	if !ok {
+		return fmt.Errorf("remote key %q not supported", name)
+	}
+
+	return d.sendIRCC(ctx, code)
+}
+
+func (d *Display) sendIRCC(ctx context.Context, code string) error {
+	d.once.Do(d.init)
+
+	var escaped bytes.Buffer
+	if err := xml.EscapeText(&escaped, []byte(code)); err != nil {
+		return fmt.Errorf("unable to escape code: %w", err)
+	}
+
+	body := []byte(fmt.Sprintf(irccEnvelope, escaped.String()))
+
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url("/sony/IRCC", false), bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("unable to build request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "text/xml; charset=UTF-8")
+	httpReq.Header.Set("SOAPACTION", `"urn:schemas-sony-com:service:IRCC:1#X_SendIRCC"`)
+	httpReq.Header.Set("X-Auth-PSK", d.PreSharedKey)
+
+	if err := d.wait(ctx); err != nil {
+		return fmt.Errorf("unable to wait for ratelimit: %w", err)
+	}
+
+	d.Log.Debug("Doing IRCC request", zap.String("url", httpReq.URL.String()), zap.String("code", code))
+
+	resp, err := d.client().Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("unable to do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return httpError(resp.StatusCode)
+}
+
+type remoteCode struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (d *Display) getRemoteControllerInfo(ctx context.Context) (map[string]string, error) {
+	req := request{
+		Version: "1.0",
+		Method:  "getRemoteControllerInfo",
+		Params:  []map[string]interface{}{},
+	}
+
+	var bundle struct {
+		Bundled bool   `json:"bundled,omitempty"`
+		Type    string `json:"type,omitempty"`
+	}
+
+	var list []remoteCode
+	if err := d.doRequest(ctx, "system", req, &bundle, &list); err != nil {
+		return nil, err
+	}
+
+	codes := make(map[string]string, len(list))
+	for _, code := range list {
+		codes[code.Name] = code.Value
+	}
+
+	return codes, nil
+}
@@ -0,0 +1,51 @@
+package bravia
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestRemoteKeys(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	keys, err := disp.RemoteKeys(ctx)
+	is.NoErr(err)
+	is.True(len(keys) > 0)
+
+	_, ok := keys["VolumeUp"]
+	is.True(ok)
+
+	// changing the returned keys doesn't change the cache
+	delete(keys, "VolumeUp")
+
+	keys, err = disp.RemoteKeys(ctx)
+	is.NoErr(err)
+
+	_, ok = keys["VolumeUp"]
+	is.True(ok)
+}
+
+func TestSendRemoteKey(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	is.NoErr(disp.SendRemoteKey(ctx, "Home"))
+	is.True(disp.SendRemoteKey(ctx, "NotAKey") != nil)
+
+	if srv != nil {
+		pressed := srv.State().Pressed
+		is.True(len(pressed) > 0)
+		is.Equal(pressed[len(pressed)-1], "Home")
+	}
+}
@@ -0,0 +1,377 @@
+package bravia
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+const (
+	_notifyTimeout  = 10 * time.Second
+	_minReconnect   = time.Second
+	_maxReconnect   = 30 * time.Second
+	_eventQueueSize = 16
+)
+
+var (
+	// how often notification connections are pinged, and how long they can go without a pong
+	// before they're treated as dropped (ie, when the display is unplugged)
+	_pingInterval = 20 * time.Second
+	_pongWait     = 45 * time.Second
+)
+
+// Event is a notification sent by the display. It is one of PowerEvent, VolumeEvent, or InputEvent.
+type Event interface {
+	event()
+}
+
+// PowerEvent is sent when the display is turned on or off.
+type PowerEvent struct {
+	Power bool
+}
+
+// VolumeEvent is sent when the volume or mute of a target changes.
+type VolumeEvent struct {
+	Target string
+	Volume int
+	Mute   bool
+}
+
+// InputEvent is sent when the input changes. Input is in the same format as AudioVideoInputs.
+type InputEvent struct {
+	Input string
+}
+
+func (PowerEvent) event()  {}
+func (VolumeEvent) event() {}
+func (InputEvent) event()  {}
+
+// notifyServices maps each service to the notifications Subscribe enables on it.
+var notifyServices = map[string][]string{
+	"system":    {"notifyPowerStatus"},
+	"audio":     {"notifyVolumeInformation"},
+	"avContent": {"notifyPlayingContentInfo"},
+}
+
+type notificationName struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type notificationStatus struct {
+	Enabled  []notificationName `json:"enabled"`
+	Disabled []notificationName `json:"disabled"`
+}
+
+type notification struct {
+	ID      int               `json:"id"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+	Result  json.RawMessage   `json:"result"`
+	Error   []interface{}     `json:"error"`
+	Version string            `json:"version"`
+}
+
+// Subscribe opens websocket connections to the display and enables power, volume, and input notifications.
+// Events are sent on the returned channel until ctx is cancelled, after which the channel is closed.
+// If NormalizeVolume is true, volume events for targets whose range can't be learned are dropped.
+// If a connection drops, it is reopened and its notifications are enabled again.
+func (d *Display) Subscribe(ctx context.Context) (<-chan Event, error) {
+	d.once.Do(d.init)
+
+	if d.NormalizeVolume {
+		// learn the volume ranges now, instead of when the first volume event arrives
+		if _, err := d.getVolumeInformation(ctx); err != nil {
+			d.Log.Debug("Unable to get volume ranges", zap.Error(err))
+		}
+	}
+
+	conns := make(map[string]*websocket.Conn, len(notifyServices))
+	for service, names := range notifyServices {
+		conn, err := d.subscribe(ctx, service, names)
+		if err != nil {
+			for _, conn := range conns {
+				conn.Close()
+			}
+
+			return nil, fmt.Errorf("unable to subscribe to %s: %w", service, err)
+		}
+
+		conns[service] = conn
+	}
+
+	events := make(chan Event, _eventQueueSize)
+	wg := sync.WaitGroup{}
+
+	for service, conn := range conns {
+		wg.Add(1)
+
+		go func(service string, conn *websocket.Conn) {
+			defer wg.Done()
+			d.watch(ctx, service, conn, events)
+		}(service, conn)
+	}
+
+	go func() {
+		wg.Wait()
+		close(events)
+	}()
+
+	return events, nil
+}
+
+// watch reads notifications from conn, reconnecting to service whenever the connection drops, until ctx is done.
+func (d *Display) watch(ctx context.Context, service string, conn *websocket.Conn, events chan<- Event) {
+	for {
+		err := d.readNotifications(ctx, conn, events)
+		if ctx.Err() != nil {
+			return
+		}
+
+		d.Log.Warn("Lost notification connection", zap.String("service", service), zap.Error(err))
+
+		delay := _minReconnect
+		for {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return
+			}
+
+			conn, err = d.subscribe(ctx, service, notifyServices[service])
+			if err == nil {
+				break
+			}
+
+			d.Log.Warn("Unable to reconnect to notifications", zap.String("service", service), zap.Error(err))
+
+			delay *= 2
+			if delay > _maxReconnect {
+				delay = _maxReconnect
+			}
+		}
+
+		d.Log.Info("Reconnected to notifications", zap.String("service", service))
+	}
+}
+
+func (d *Display) readNotifications(ctx context.Context, conn *websocket.Conn, events chan<- Event) error {
+	done := make(chan struct{})
+	defer close(done)
+
+	// a connection that silently stopped working can only be noticed by it not answering pings
+	conn.SetReadDeadline(time.Now().Add(_pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(_pongWait))
+	})
+
+	// ping the display, and unblock ReadJSON when ctx is done
+	go func() {
+		defer conn.Close()
+
+		ticker := time.NewTicker(_pingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(_notifyTimeout)); err != nil {
+					d.Log.Debug("Unable to ping notification connection", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for {
+		var n notification
+		if err := conn.ReadJSON(&n); err != nil {
+			return err
+		}
+
+		conn.SetReadDeadline(time.Now().Add(_pongWait))
+
+		d.Log.Debug("Notification", zap.Any("notification", n))
+
+		event, err := parseEvent(n)
+		switch {
+		case err != nil:
+			d.Log.Warn("Unable to parse notification", zap.String("method", n.Method), zap.Error(err))
+			continue
+		case event == nil:
+			continue
+		}
+
+		if vol, ok := event.(VolumeEvent); ok && d.NormalizeVolume {
+			// never mix the display's own levels in with normalized ones
+			if vol.Volume, ok = d.normalizeNotified(ctx, vol.Target, vol.Volume); !ok {
+				d.Log.Warn("Dropping volume event for target with unknown range", zap.String("target", vol.Target))
+				continue
+			}
+
+			event = vol
+		}
+
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// subscribe connects to service's websocket and enables the notifications in names.
+func (d *Display) subscribe(ctx context.Context, service string, names []string) (*websocket.Conn, error) {
+	header := http.Header{}
+	header.Set("X-Auth-PSK", d.PreSharedKey)
+
+	dialCtx, cancel := context.WithTimeout(ctx, _notifyTimeout)
+	defer cancel()
+
+	conn, resp, err := d.dialer().DialContext(dialCtx, d.url("/sony/"+service, true), header)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("unable to dial: %w", httpError(resp.StatusCode))
+		}
+
+		return nil, fmt.Errorf("unable to dial: %w", err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(_notifyTimeout))
+	conn.SetWriteDeadline(time.Now().Add(_notifyTimeout))
+
+	// find out which versions of each notification the display supports
+	status, err := switchNotifications(conn, notificationStatus{})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	var enable notificationStatus
+	for _, name := range names {
+		for _, n := range append(status.Enabled, status.Disabled...) {
+			if n.Name == name {
+				enable.Enabled = append(enable.Enabled, n)
+				break
+			}
+		}
+	}
+
+	if len(enable.Enabled) == 0 {
+		conn.Close()
+		return nil, fmt.Errorf("display does not support %v", names)
+	}
+
+	if _, err := switchNotifications(conn, enable); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	conn.SetReadDeadline(time.Time{})
+	conn.SetWriteDeadline(time.Time{})
+
+	return conn, nil
+}
+
+func switchNotifications(conn *websocket.Conn, params notificationStatus) (notificationStatus, error) {
+	if params.Enabled == nil {
+		params.Enabled = []notificationName{}
+	}
+
+	if params.Disabled == nil {
+		params.Disabled = []notificationName{}
+	}
+
+	req := struct {
+		ID      int                  `json:"id"`
+		Method  string               `json:"method"`
+		Version string               `json:"version"`
+		Params  []notificationStatus `json:"params"`
+	}{
+		ID:      rand.Intn(_maxID-1) + 1,
+		Method:  "switchNotifications",
+		Version: "1.0",
+		Params:  []notificationStatus{params},
+	}
+
+	if err := conn.WriteJSON(req); err != nil {
+		return notificationStatus{}, fmt.Errorf("unable to write request: %w", err)
+	}
+
+	for {
+		var n notification
+		if err := conn.ReadJSON(&n); err != nil {
+			return notificationStatus{}, fmt.Errorf("unable to read response: %w", err)
+		}
+
+		if n.ID != req.ID {
+			continue
+		}
+
+		res := response{Error: n.Error}
+		if err := res.BuildError(); err != nil {
+			return notificationStatus{}, err
+		}
+
+		var result []notificationStatus
+		if err := json.Unmarshal(n.Result, &result); err != nil || len(result) < 1 {
+			return notificationStatus{}, fmt.Errorf("unexpected response: %s", n.Result)
+		}
+
+		return result[0], nil
+	}
+}
+
+func parseEvent(n notification) (Event, error) {
+	if len(n.Params) < 1 {
+		return nil, nil
+	}
+
+	switch n.Method {
+	case "notifyPowerStatus":
+		var params struct {
+			Status string `json:"status"`
+		}
+
+		if err := json.Unmarshal(n.Params[0], &params); err != nil {
+			return nil, err
+		}
+
+		return PowerEvent{Power: params.Status == "active"}, nil
+	case "notifyVolumeInformation":
+		var params struct {
+			Target string `json:"target"`
+			Volume int    `json:"volume"`
+			Mute   bool   `json:"mute"`
+		}
+
+		if err := json.Unmarshal(n.Params[0], &params); err != nil {
+			return nil, err
+		}
+
+		return VolumeEvent{Target: params.Target, Volume: params.Volume, Mute: params.Mute}, nil
+	case "notifyPlayingContentInfo":
+		var params struct {
+			URI string `json:"uri"`
+		}
+
+		if err := json.Unmarshal(n.Params[0], &params); err != nil {
+			return nil, err
+		}
+
+		return InputEvent{Input: strings.TrimPrefix(params.URI, "extInput:")}, nil
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,188 @@
+package bravia
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/byuoitav/sony/bravia/braviatest"
+	"github.com/matryer/is"
+	"go.uber.org/zap/zaptest"
+)
+
+func waitForEvent(t *testing.T, events <-chan Event, match func(Event) bool) {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatal("event channel closed")
+			}
+
+			if match(event) {
+				return
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for event")
+		}
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	subCtx, subCancel := context.WithCancel(ctx)
+	events, err := disp.Subscribe(subCtx)
+	is.NoErr(err)
+
+	is.NoErr(disp.SetPower(ctx, true))
+	waitForEvent(t, events, func(e Event) bool {
+		return e == PowerEvent{Power: true}
+	})
+
+	inputs, err := disp.AudioVideoInputs(ctx)
+	is.NoErr(err)
+
+	input := "hdmi?port=1"
+	if inputs[""] == input {
+		input = "hdmi?port=2"
+	}
+
+	is.NoErr(disp.SetAudioVideoInput(ctx, "", input))
+	waitForEvent(t, events, func(e Event) bool {
+		return e == InputEvent{Input: input}
+	})
+
+	is.NoErr(disp.SetMute(ctx, "speaker", true))
+	waitForEvent(t, events, func(e Event) bool {
+		v, ok := e.(VolumeEvent)
+		return ok && v.Target == "speaker" && v.Mute
+	})
+
+	is.NoErr(disp.SetMute(ctx, "speaker", false))
+
+	if srv != nil {
+		// drop the connections and make sure notifications come back
+		srv.CloseNotifications()
+		time.Sleep(1500 * time.Millisecond)
+
+		srv.SetState(func(s *braviatest.State) {
+			s.Power = false
+		})
+
+		waitForEvent(t, events, func(e Event) bool {
+			return e == PowerEvent{Power: false}
+		})
+	}
+
+	is.NoErr(disp.SetPower(ctx, false))
+
+	subCancel()
+	for range events {
+	}
+}
+
+func TestSubscribeNormalizeVolume(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv := braviatest.NewServer("")
+	defer srv.Close()
+
+	srv.SetState(func(s *braviatest.State) {
+		s.Power = true
+		s.Volumes = []braviatest.Volume{
+			{Target: "speaker", Volume: 10, MinVolume: 0, MaxVolume: 50},
+		}
+	})
+
+	d := &Display{
+		Address:         srv.Address(),
+		Log:             zaptest.NewLogger(t),
+		RequestDelay:    10 * time.Millisecond,
+		NormalizeVolume: true,
+	}
+
+	// the ranges can't be learned when subscribing
+	srv.SetError("getVolumeInformation", braviatest.CodeDisplayOff)
+
+	events, err := d.Subscribe(ctx)
+	is.NoErr(err)
+
+	srv.ClearError("getVolumeInformation")
+
+	// the first event is normalized too
+	srv.SetState(func(s *braviatest.State) {
+		s.Volumes[0].Volume = 25
+	})
+
+	waitForEvent(t, events, func(e Event) bool {
+		v, ok := e.(VolumeEvent)
+		if ok {
+			is.Equal(v.Volume, 50)
+		}
+
+		return ok
+	})
+
+	srv.SetState(func(s *braviatest.State) {
+		s.Volumes[0].Volume = 5
+	})
+
+	waitForEvent(t, events, func(e Event) bool {
+		v, ok := e.(VolumeEvent)
+		if ok {
+			is.Equal(v.Volume, 10)
+		}
+
+		return ok
+	})
+}
+
+func TestSubscribeHungConnection(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pingInterval, pongWait := _pingInterval, _pongWait
+	_pingInterval, _pongWait = 100*time.Millisecond, 300*time.Millisecond
+
+	defer func() {
+		_pingInterval, _pongWait = pingInterval, pongWait
+	}()
+
+	srv := braviatest.NewServer("")
+	defer srv.Close()
+
+	d := &Display{
+		Address:      srv.Address(),
+		Log:          zaptest.NewLogger(t),
+		RequestDelay: 10 * time.Millisecond,
+	}
+
+	events, err := d.Subscribe(ctx)
+	is.NoErr(err)
+
+	// the connections stay open, but nothing comes through them
+	srv.HangNotifications()
+
+	// wait for the missing pongs to be noticed, and for the connections to be reopened
+	time.Sleep(_pongWait + _minReconnect + 500*time.Millisecond)
+
+	srv.SetState(func(s *braviatest.State) {
+		s.Power = true
+	})
+
+	waitForEvent(t, events, func(e Event) bool {
+		return e == PowerEvent{Power: true}
+	})
+}
@@ -0,0 +1,222 @@
+package bravia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// AudioOutput is where the display sends its audio, set by the outputTerminal sound setting.
+type AudioOutput string
+
+const (
+	// AudioOutputSpeaker plays audio through the display's internal speakers.
+	AudioOutputSpeaker AudioOutput = "speaker"
+
+	// AudioOutputSpeakerHDMI plays audio through both the internal speakers and HDMI (ARC/eARC).
+	AudioOutputSpeakerHDMI AudioOutput = "speaker_hdmi"
+
+	// AudioOutputHDMI plays audio only through HDMI (ARC/eARC).
+	AudioOutputHDMI AudioOutput = "hdmi"
+
+	// AudioOutputAudioSystem plays audio through an audio system (ie, a soundbar) connected with ARC/eARC.
+	AudioOutputAudioSystem AudioOutput = "audioSystem"
+
+	// AudioOutputHeadphone plays audio through the headphone jack, on displays that support it.
+	AudioOutputHeadphone AudioOutput = "headphone"
+)
+
+// TVPosition is how the display is installed, set by the tvPosition speaker setting.
+// The display tunes its sound for the position.
+type TVPosition string
+
+const (
+	// TVPositionTableTop is a display standing on a table or stand.
+	TVPositionTableTop TVPosition = "tableTop"
+
+	// TVPositionWallMount is a display mounted on a wall.
+	TVPositionWallMount TVPosition = "wallMount"
+)
+
+type setting struct {
+	Target       string `json:"target"`
+	CurrentValue string `json:"currentValue"`
+}
+
+// SoundSettings returns the display's sound settings (ie, "outputTerminal"), mapped from target to value.
+func (d *Display) SoundSettings(ctx context.Context) (map[string]string, error) {
+	return d.getSettings(ctx, "getSoundSettings", "1.1")
+}
+
+// SetSoundSetting sets the sound setting target to value, and waits for the display to report the new value.
+func (d *Display) SetSoundSetting(ctx context.Context, target, value string) error {
+	return d.setSetting(ctx, "setSoundSettings", "1.1", target, value, d.SoundSettings)
+}
+
+// SpeakerSettings returns the display's speaker settings (ie, "tvPosition" or "subwooferLevel"), mapped from target to value.
+func (d *Display) SpeakerSettings(ctx context.Context) (map[string]string, error) {
+	return d.getSettings(ctx, "getSpeakerSettings", "1.0")
+}
+
+// SetSpeakerSetting sets the speaker setting target to value, and waits for the display to report the new value.
+func (d *Display) SetSpeakerSetting(ctx context.Context, target, value string) error {
+	return d.setSetting(ctx, "setSpeakerSettings", "1.0", target, value, d.SpeakerSettings)
+}
+
+// AudioOutput returns where the display is currently sending its audio.
+func (d *Display) AudioOutput(ctx context.Context) (AudioOutput, error) {
+	settings, err := d.SoundSettings(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	output, ok := settings["outputTerminal"]
+	if !ok {
+		return "", fmt.Errorf("outputTerminal: %w", ErrUnsupported)
+	}
+
+	return AudioOutput(output), nil
+}
+
+// SetAudioOutput switches where the display sends its audio.
+func (d *Display) SetAudioOutput(ctx context.Context, output AudioOutput) error {
+	return d.SetSoundSetting(ctx, "outputTerminal", string(output))
+}
+
+// TVPosition returns how the display is set as being installed.
+func (d *Display) TVPosition(ctx context.Context) (TVPosition, error) {
+	position, err := d.speakerSetting(ctx, "tvPosition")
+	if err != nil {
+		return "", err
+	}
+
+	return TVPosition(position), nil
+}
+
+// SetTVPosition sets how the display is installed.
+func (d *Display) SetTVPosition(ctx context.Context, position TVPosition) error {
+	return d.SetSpeakerSetting(ctx, "tvPosition", string(position))
+}
+
+// SubwooferLevel returns the level of the display's wireless subwoofer.
+func (d *Display) SubwooferLevel(ctx context.Context) (int, error) {
+	level, err := d.speakerSetting(ctx, "subwooferLevel")
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := strconv.Atoi(level)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse subwooferLevel %q: %w", level, err)
+	}
+
+	return n, nil
+}
+
+// SetSubwooferLevel sets the level of the display's wireless subwoofer. The range of levels depends on the model.
+func (d *Display) SetSubwooferLevel(ctx context.Context, level int) error {
+	if level < 0 {
+		return fmt.Errorf("subwooferLevel %d: %w", level, ErrIllegalArgument)
+	}
+
+	return d.SetSpeakerSetting(ctx, "subwooferLevel", strconv.Itoa(level))
+}
+
+// speakerSetting returns the value of the speaker setting target.
+func (d *Display) speakerSetting(ctx context.Context, target string) (string, error) {
+	settings, err := d.SpeakerSettings(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := settings[target]
+	if !ok {
+		return "", fmt.Errorf("%s: %w", target, ErrUnsupported)
+	}
+
+	return value, nil
+}
+
+// activeTargets returns the volume targets in infos that are in use for the display's current audio output.
+// If the display can't report its output, every target is returned.
+func (d *Display) activeTargets(ctx context.Context, infos []volumeInformation) ([]string, error) {
+	output, err := d.AudioOutput(ctx)
+	if err != nil && !errors.Is(err, ErrUnsupported) {
+		return nil, err
+	}
+
+	var targets []string
+	for _, info := range infos {
+		switch {
+		case output == "":
+		case output == AudioOutputHeadphone && info.Target != "headphone":
+			continue
+		case output != AudioOutputHeadphone && info.Target == "headphone":
+			continue
+		}
+
+		targets = append(targets, info.Target)
+	}
+
+	return targets, nil
+}
+
+func (d *Display) getSettings(ctx context.Context, method, version string) (map[string]string, error) {
+	req := request{
+		Version: version,
+		Method:  method,
+		Params: []map[string]interface{}{
+			{
+				"target": "",
+			},
+		},
+	}
+
+	var list []setting
+	if err := d.doRequest(ctx, "audio", req, &list); err != nil {
+		if errors.Is(err, ErrDisplayOff) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	settings := make(map[string]string, len(list))
+	for _, s := range list {
+		settings[s.Target] = s.CurrentValue
+	}
+
+	return settings, nil
+}
+
+func (d *Display) setSetting(ctx context.Context, method, version, target, value string, get func(context.Context) (map[string]string, error)) error {
+	req := request{
+		Version: version,
+		Method:  method,
+		Params: []map[string]interface{}{
+			{
+				"settings": []map[string]string{
+					{
+						"target": target,
+						"value":  value,
+					},
+				},
+			},
+		},
+	}
+
+	if err := d.doRequest(ctx, "audio", req); err != nil {
+		return err
+	}
+
+	// wait for the setting to change
+	return d.confirm(ctx, target, value, func(ctx context.Context) (interface{}, error) {
+		settings, err := get(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return settings[target], nil
+	})
+}
@@ -0,0 +1,112 @@
+package bravia
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestAudioOutput(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	is.NoErr(disp.SetPower(ctx, true))
+
+	orig, err := disp.AudioOutput(ctx)
+	is.NoErr(err)
+
+	is.NoErr(disp.SetAudioOutput(ctx, AudioOutputAudioSystem))
+
+	output, err := disp.AudioOutput(ctx)
+	is.NoErr(err)
+	is.Equal(output, AudioOutputAudioSystem)
+
+	vols, err := disp.Volumes(ctx, nil)
+	is.NoErr(err)
+	_, ok := vols["headphone"]
+	is.True(!ok)
+
+	if srv != nil {
+		is.NoErr(disp.SetAudioOutput(ctx, AudioOutputHeadphone))
+
+		vols, err = disp.Volumes(ctx, nil)
+		is.NoErr(err)
+		is.Equal(len(vols), 1)
+		_, ok = vols["headphone"]
+		is.True(ok)
+	}
+
+	is.NoErr(disp.SetAudioOutput(ctx, orig))
+	is.NoErr(disp.SetPower(ctx, false))
+}
+
+func TestSpeakerSettings(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	is.NoErr(disp.SetPower(ctx, true))
+
+	settings, err := disp.SpeakerSettings(ctx)
+	is.NoErr(err)
+
+	for target, value := range settings {
+		t.Logf("%s: %s", target, value)
+	}
+
+	orig, ok := settings["tvPosition"]
+	is.True(ok)
+
+	is.NoErr(disp.SetSpeakerSetting(ctx, "tvPosition", "wallMount"))
+
+	settings, err = disp.SpeakerSettings(ctx)
+	is.NoErr(err)
+	is.Equal(settings["tvPosition"], "wallMount")
+
+	is.NoErr(disp.SetSpeakerSetting(ctx, "tvPosition", orig))
+	is.NoErr(disp.SetPower(ctx, false))
+}
+
+func TestTypedSpeakerSettings(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	is.NoErr(disp.SetPower(ctx, true))
+
+	position, err := disp.TVPosition(ctx)
+	is.NoErr(err)
+
+	is.NoErr(disp.SetTVPosition(ctx, TVPositionWallMount))
+
+	got, err := disp.TVPosition(ctx)
+	is.NoErr(err)
+	is.Equal(got, TVPositionWallMount)
+
+	is.NoErr(disp.SetTVPosition(ctx, position))
+
+	level, err := disp.SubwooferLevel(ctx)
+	is.NoErr(err)
+
+	is.NoErr(disp.SetSubwooferLevel(ctx, 7))
+
+	level2, err := disp.SubwooferLevel(ctx)
+	is.NoErr(err)
+	is.Equal(level2, 7)
+
+	is.True(errors.Is(disp.SetSubwooferLevel(ctx, -1), ErrIllegalArgument))
+
+	is.NoErr(disp.SetSubwooferLevel(ctx, level))
+	is.NoErr(disp.SetPower(ctx, false))
+}
@@ -0,0 +1,92 @@
+package bravia
+
+import (
+	"context"
+	"errors"
+)
+
+// State is a snapshot of the display's state, from State.
+type State struct {
+	Power   bool
+	Blanked bool
+
+	// Input is in the same format as AudioVideoInputs. It is empty if the display is off.
+	Input string
+
+	// Volumes and Mutes are keyed by target, and are empty if the display is off. Like Volumes with no blocks,
+	// they only hold the targets in use for the display's current audio output.
+	Volumes map[string]int
+	Mutes   map[string]bool
+
+	// Errors holds the error for each field that couldn't be read, keyed by
+	// "blank", "input", or "volume" (which covers both Volumes and Mutes).
+	Errors map[string]error
+}
+
+// State returns the power, blank, input, volume, and mute of the display in as few requests as possible.
+// It only returns an error if the power can't be read; errors reading any other field are in State.Errors.
+func (d *Display) State(ctx context.Context) (State, error) {
+	state := State{
+		Volumes: map[string]int{},
+		Mutes:   map[string]bool{},
+		Errors:  map[string]error{},
+	}
+
+	power, err := d.Power(ctx)
+	if err != nil {
+		return State{}, err
+	}
+
+	state.Power = power
+
+	blanked, err := d.Blank(ctx)
+	if err != nil {
+		state.Errors["blank"] = err
+	}
+
+	state.Blanked = blanked
+
+	if !state.Power {
+		return state, nil
+	}
+
+	inputs, err := d.AudioVideoInputs(ctx)
+	switch {
+	case err != nil:
+		state.Errors["input"] = err
+	case inputs == nil:
+		// the display turned off since getting the power
+		state.Power = false
+		return state, nil
+	default:
+		state.Input = inputs[""]
+	}
+
+	infos, err := d.getVolumeInformation(ctx)
+	switch {
+	case errors.Is(err, ErrDisplayOff):
+		state.Power = false
+		state.Input = ""
+		return state, nil
+	case err != nil:
+		state.Errors["volume"] = err
+		return state, nil
+	}
+
+	targets, err := d.activeTargets(ctx, infos)
+	if err != nil {
+		state.Errors["volume"] = err
+		return state, nil
+	}
+
+	for _, target := range targets {
+		for _, info := range infos {
+			if info.Target == target {
+				state.Volumes[info.Target] = d.normalVolume(info, info.Volume)
+				state.Mutes[info.Target] = info.Mute
+			}
+		}
+	}
+
+	return state, nil
+}
@@ -0,0 +1,120 @@
+package bravia
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/byuoitav/sony/bravia/braviatest"
+	"github.com/matryer/is"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestState(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	is.NoErr(disp.SetPower(ctx, false))
+
+	state, err := disp.State(ctx)
+	is.NoErr(err)
+	is.True(!state.Power)
+	is.Equal(len(state.Volumes), 0)
+	is.Equal(len(state.Errors), 0)
+
+	is.NoErr(disp.SetPower(ctx, true))
+
+	state, err = disp.State(ctx)
+	is.NoErr(err)
+	t.Logf("%+v", state)
+
+	is.True(state.Power)
+	is.Equal(len(state.Errors), 0)
+	is.True(state.Input != "")
+
+	vols, err := disp.Volumes(ctx, []string{"speaker"})
+	is.NoErr(err)
+	is.Equal(state.Volumes["speaker"], vols["speaker"])
+
+	is.NoErr(disp.SetPower(ctx, false))
+}
+
+func TestStateErrors(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv := braviatest.NewServer("")
+	defer srv.Close()
+
+	srv.SetState(func(s *braviatest.State) {
+		s.Power = true
+	})
+
+	d := &Display{
+		Address:      srv.Address(),
+		Log:          zaptest.NewLogger(t),
+		RequestDelay: 10 * time.Millisecond,
+	}
+
+	srv.SetError("getPowerSavingMode", braviatest.CodeIllegalState)
+
+	state, err := d.State(ctx)
+	is.NoErr(err)
+	is.True(state.Power)
+	is.True(state.Errors["blank"] != nil)
+	is.Equal(state.Input, "hdmi?port=1")
+	is.Equal(state.Volumes["speaker"], 20)
+
+	// a display that turns off mid-snapshot is reported as off
+	srv.ClearError("getPowerSavingMode")
+	srv.SetError("getVolumeInformation", braviatest.CodeDisplayOff)
+
+	state, err = d.State(ctx)
+	is.NoErr(err)
+	is.True(!state.Power)
+	is.Equal(state.Input, "")
+	is.Equal(len(state.Errors), 0)
+
+	srv.SetError("getPowerStatus", braviatest.CodeIllegalState)
+	_, err = d.State(ctx)
+	is.True(err != nil)
+}
+
+func TestStateAudioOutput(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv := braviatest.NewServer("")
+	defer srv.Close()
+
+	srv.SetState(func(s *braviatest.State) {
+		s.Power = true
+	})
+
+	d := &Display{
+		Address:      srv.Address(),
+		Log:          zaptest.NewLogger(t),
+		RequestDelay: 10 * time.Millisecond,
+	}
+
+	is.NoErr(d.SetAudioOutput(ctx, AudioOutputHeadphone))
+
+	// only the targets Volumes reports are in the snapshot
+	state, err := d.State(ctx)
+	is.NoErr(err)
+	is.Equal(len(state.Errors), 0)
+
+	vols, err := d.Volumes(ctx, nil)
+	is.NoErr(err)
+	is.Equal(state.Volumes, vols)
+	is.Equal(len(state.Mutes), 1)
+	_, ok := state.Mutes["headphone"]
+	is.True(ok)
+}
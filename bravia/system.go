@@ -2,8 +2,10 @@ package bravia
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"time"
+
+	"go.uber.org/zap"
 )
 
 func (d *Display) Power(ctx context.Context) (bool, error) {
@@ -13,25 +15,15 @@ func (d *Display) Power(ctx context.Context) (bool, error) {
 		Params:  []map[string]interface{}{},
 	}
 
-	res, err := d.doRequest(ctx, "system", req)
-	switch {
-	case err != nil:
-		return false, err
-	case len(res) < 1:
-		return false, fmt.Errorf("unexpected response: %+v", res)
-	}
-
-	m, ok := res[0].(map[string]interface{})
-	if !ok {
-		return false, fmt.Errorf("unexpected response: %+v", res)
+	var status struct {
+		Status string `json:"status"`
 	}
 
-	str, ok := m["status"].(string)
-	if !ok {
-		return false, fmt.Errorf("unexpected response: %+v", res)
+	if err := d.doRequest(ctx, "system", req, &status); err != nil {
+		return false, err
 	}
 
-	return str == "active", nil
+	return status.Status == "active", nil
 }
 
 func (d *Display) SetPower(ctx context.Context, power bool) error {
@@ -45,29 +37,26 @@ func (d *Display) SetPower(ctx context.Context, power bool) error {
 		},
 	}
 
-	_, err := d.doRequest(ctx, "system", req)
-	if err != nil {
+	err := d.doRequest(ctx, "system", req)
+	var bErr *Error
+	switch {
+	case err == nil:
+		d.learnWake(ctx)
+	case power && !errors.As(err, &bErr) && ctx.Err() == nil:
+		// the display isn't reachable, probably because it's in deep standby
+		d.Log.Info("Unable to reach display, trying Wake-on-LAN", zap.Error(err))
+
+		if err := d.wake(ctx, req); err != nil {
+			return err
+		}
+	default:
 		return err
 	}
 
 	// wait for display to turn on
-	ticker := time.NewTicker(500 * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			pow, err := d.Power(ctx)
-			switch {
-			case err != nil:
-				return fmt.Errorf("unable to confirm power set: %w", err)
-			case pow == power:
-				return nil
-			}
-		case <-ctx.Done():
-			return fmt.Errorf("unable to confirm power set: %w", ctx.Err())
-		}
-	}
+	return d.confirm(ctx, "power", power, func(ctx context.Context) (interface{}, error) {
+		return d.Power(ctx)
+	})
 }
 
 func (d *Display) Blank(ctx context.Context) (bool, error) {
@@ -77,25 +66,15 @@ func (d *Display) Blank(ctx context.Context) (bool, error) {
 		Params:  []map[string]interface{}{},
 	}
 
-	res, err := d.doRequest(ctx, "system", req)
-	switch {
-	case err != nil:
-		return false, err
-	case len(res) < 1:
-		return false, fmt.Errorf("unexpected response: %+v", res)
-	}
-
-	m, ok := res[0].(map[string]interface{})
-	if !ok {
-		return false, fmt.Errorf("unexpected response: %+v", res)
+	var mode struct {
+		Mode string `json:"mode"`
 	}
 
-	str, ok := m["mode"].(string)
-	if !ok {
-		return false, fmt.Errorf("unexpected response: %+v", res)
+	if err := d.doRequest(ctx, "system", req, &mode); err != nil {
+		return false, err
 	}
 
-	return str == "pictureOff", nil
+	return mode.Mode == "pictureOff", nil
 }
 
 func (d *Display) SetBlank(ctx context.Context, blanked bool) error {
@@ -114,29 +93,14 @@ func (d *Display) SetBlank(ctx context.Context, blanked bool) error {
 		},
 	}
 
-	_, err := d.doRequest(ctx, "system", req)
-	if err != nil {
+	if err := d.doRequest(ctx, "system", req); err != nil {
 		return err
 	}
 
 	// wait for display to blank
-	ticker := time.NewTicker(500 * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			b, err := d.Blank(ctx)
-			switch {
-			case err != nil:
-				return fmt.Errorf("unable to confirm blank set: %w", err)
-			case b == blanked:
-				return nil
-			}
-		case <-ctx.Done():
-			return fmt.Errorf("unable to confirm blank set: %w", ctx.Err())
-		}
-	}
+	return d.confirm(ctx, "blank", blanked, func(ctx context.Context) (interface{}, error) {
+		return d.Blank(ctx)
+	})
 }
 
 type Info struct {
@@ -156,135 +120,34 @@ func (d *Display) Info(ctx context.Context) (interface{}, error) {
 		Params:  []map[string]interface{}{},
 	}
 
-	res, err := d.doRequest(ctx, "system", req)
-	switch {
-	case err != nil:
-		return nil, err
-	case len(res) < 1:
-		return nil, fmt.Errorf("unexpected response: %+v", res)
-	}
-
-	m, ok := res[0].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("unexpected response: %+v", res)
-	}
-
 	var info Info
-
-	info.Product, ok = m["product"].(string)
-	if !ok {
-		return info, fmt.Errorf("unexpected response: %+v", res)
-	}
-
-	info.Language, ok = m["language"].(string)
-	if !ok {
-		return info, fmt.Errorf("unexpected response: %+v", res)
-	}
-
-	info.Model, ok = m["model"].(string)
-	if !ok {
-		return info, fmt.Errorf("unexpected response: %+v", res)
-	}
-
-	info.Serial, ok = m["serial"].(string)
-	if !ok {
-		return info, fmt.Errorf("unexpected response: %+v", res)
-	}
-
-	info.MACAddress, ok = m["macAddr"].(string)
-	if !ok {
-		return info, fmt.Errorf("unexpected response: %+v", res)
-	}
-
-	info.Name, ok = m["name"].(string)
-	if !ok {
-		return info, fmt.Errorf("unexpected response: %+v", res)
-	}
-
-	info.Generation, ok = m["generation"].(string)
-	if !ok {
-		return info, fmt.Errorf("unexpected response: %+v", res)
+	if err := d.doRequest(ctx, "system", req, &info); err != nil {
+		return nil, err
 	}
 
 	return info, nil
 }
 
+// Healthy returns an error if the display can't be reached, or if it doesn't support the APIs needed to control it.
 func (d *Display) Healthy(ctx context.Context) error {
-	//get list of supported apis and check to see if the newer api is supported
-
-	req := request{
-		Version: "1.0",
-		Method:  "getSupportedApiInfo",
-		Params: []map[string]interface{}{
-			{
-				"services": nil,
-			},
-		},
-	}
-
-	found := false
-	done := false
-
-	res, err := d.doRequest(ctx, "guide", req)
-	switch {
-	case err != nil:
+	caps, err := d.capabilities(ctx)
+	if err != nil {
 		return err
-	case len(res) < 1:
-		return fmt.Errorf("unexpected response: %+v", res)
 	}
 
-	list := res[0].([]interface{})
-
-	for _, item := range list {
-		if done {
-			break
-		}
-
-		tmp, ok := item.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		apis, ok := tmp["apis"].([]interface{})
-		if !ok {
-			continue
-		}
-
-		for _, api := range apis {
-			if done {
-				break
-			}
-			a, ok := api.(map[string]interface{})
-			if !ok {
-				continue
-			}
-
-			if a["name"] == "setAudioVolume" {
-				versions, ok := a["versions"].([]interface{})
-				if !ok {
-					return fmt.Errorf("unable to convert setAudioVolume versions")
-				}
-
-				for _, version := range versions {
-					v, ok := version.(map[string]interface{})
-					if !ok {
-						fmt.Errorf("unable to convert setAudioVolume version")
-					}
-
-					if v["version"] == "1.2" {
-						found = true
-					}
-				}
+	required := map[string][]string{
+		"system":    {"getPowerStatus", "setPowerStatus"},
+		"audio":     {"getVolumeInformation", "setAudioVolume", "setAudioMute"},
+		"avContent": {"getPlayingContentInfo", "setPlayContent"},
+	}
 
-				done = true
-				break
+	for service, methods := range required {
+		for _, method := range methods {
+			if len(caps.Versions(service, method)) == 0 {
+				return fmt.Errorf("%s.%s: %w", service, method, ErrUnsupported)
 			}
 		}
 	}
 
-	if found {
-		return nil
-	}
-
-	return fmt.Errorf("unsupported api")
+	return nil
 }
@@ -0,0 +1,84 @@
+package bravia
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// DefaultTimeout is how long a request to the display can take when
+	// neither the context nor Display.Timeout set a limit.
+	DefaultTimeout = 10 * time.Second
+)
+
+// url returns the url of path on the display, using the display's scheme and port.
+// If websocket is true, the matching websocket scheme is used instead.
+func (d *Display) url(path string, websocket bool) string {
+	scheme := d.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+
+	if websocket {
+		switch scheme {
+		case "https":
+			scheme = "wss"
+		default:
+			scheme = "ws"
+		}
+	}
+
+	host := d.Address
+	if _, _, err := net.SplitHostPort(host); err != nil && d.Port != 0 {
+		host = net.JoinHostPort(host, strconv.Itoa(d.Port))
+	}
+
+	return scheme + "://" + host + path
+}
+
+// client returns the http client to make requests with.
+func (d *Display) client() *http.Client {
+	if d.HTTPClient != nil {
+		return d.HTTPClient
+	}
+
+	return http.DefaultClient
+}
+
+// dialer returns the websocket dialer to connect with, sharing the
+// proxy and tls settings of the http client's transport if it has them.
+func (d *Display) dialer() *websocket.Dialer {
+	transport, ok := d.client().Transport.(*http.Transport)
+	if !ok {
+		return websocket.DefaultDialer
+	}
+
+	dialer := *websocket.DefaultDialer
+	dialer.Proxy = transport.Proxy
+	dialer.TLSClientConfig = transport.TLSClientConfig
+	if transport.DialContext != nil {
+		dialer.NetDialContext = transport.DialContext
+	}
+
+	return &dialer
+}
+
+// withTimeout returns a copy of ctx that is canceled after the display's timeout,
+// unless ctx already has a deadline.
+func (d *Display) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+
+	timeout := d.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
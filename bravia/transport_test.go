@@ -0,0 +1,87 @@
+package bravia
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/byuoitav/sony/bravia/braviatest"
+	"github.com/matryer/is"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestURL(t *testing.T) {
+	tests := []struct {
+		disp      *Display
+		websocket bool
+		url       string
+	}{
+		{disp: &Display{Address: "tv.example.com"}, url: "http://tv.example.com/sony/system"},
+		{disp: &Display{Address: "tv.example.com", Scheme: "https"}, url: "https://tv.example.com/sony/system"},
+		{disp: &Display{Address: "tv.example.com", Port: 8080}, url: "http://tv.example.com:8080/sony/system"},
+		{disp: &Display{Address: "tv.example.com:80", Port: 8080}, url: "http://tv.example.com:80/sony/system"},
+		{disp: &Display{Address: "tv.example.com"}, websocket: true, url: "ws://tv.example.com/sony/system"},
+		{disp: &Display{Address: "tv.example.com", Scheme: "https", Port: 8443}, websocket: true, url: "wss://tv.example.com:8443/sony/system"},
+	}
+
+	for _, tt := range tests {
+		is := is.New(t)
+		is.Equal(tt.disp.url("/sony/system", tt.websocket), tt.url)
+	}
+}
+
+func TestHTTPS(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv := braviatest.NewTLSServer("")
+	defer srv.Close()
+
+	d := &Display{
+		Address:      srv.Address(),
+		Log:          zaptest.NewLogger(t),
+		RequestDelay: 10 * time.Millisecond,
+		HTTPClient:   srv.Client(),
+		Scheme:       "https",
+	}
+
+	events, err := d.Subscribe(ctx)
+	is.NoErr(err)
+
+	is.NoErr(d.SetPower(ctx, true))
+	is.True(srv.State().Power)
+
+	for event := range events {
+		if pow, ok := event.(PowerEvent); ok {
+			is.True(pow.Power)
+			break
+		}
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	is := is.New(t)
+
+	done := make(chan struct{})
+	hang := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer hang.Close()
+	defer close(done)
+
+	d := &Display{
+		Address: hang.Listener.Addr().String(),
+		Log:     zaptest.NewLogger(t),
+		Timeout: 100 * time.Millisecond,
+	}
+
+	start := time.Now()
+	_, err := d.Power(context.Background())
+	is.True(errors.Is(err, context.DeadlineExceeded))
+	is.True(time.Since(start) < 5*time.Second)
+}
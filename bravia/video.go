@@ -0,0 +1,159 @@
+package bravia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// PictureSetting is a picture quality setting (ie, "pictureMode" or "brightness") and the values it can be set to.
+type PictureSetting struct {
+	Target    string
+	Value     string
+	Available bool
+
+	// Candidates are the values the setting can be set to, for settings
+	// with a fixed list of values (ie, "pictureMode").
+	Candidates []string
+
+	// Range is the range of the setting, for numeric settings (ie, "brightness").
+	Range *PictureRange
+}
+
+// PictureRange is the range of a numeric picture setting.
+type PictureRange struct {
+	Min  int
+	Max  int
+	Step int
+}
+
+type pictureQualitySetting struct {
+	Target       string             `json:"target"`
+	CurrentValue string             `json:"currentValue"`
+	IsAvailable  *bool              `json:"isAvailable,omitempty"`
+	Candidate    []pictureCandidate `json:"candidate,omitempty"`
+}
+
+type pictureCandidate struct {
+	Value string `json:"value,omitempty"`
+	Min   *int   `json:"min,omitempty"`
+	Max   *int   `json:"max,omitempty"`
+	Step  *int   `json:"step,omitempty"`
+}
+
+// PictureSettings returns the display's picture quality settings, keyed by target.
+func (d *Display) PictureSettings(ctx context.Context) (map[string]PictureSetting, error) {
+	service, err := d.pictureService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := request{
+		Version: "1.0",
+		Method:  "getPictureQualitySettings",
+		Params: []map[string]interface{}{
+			{
+				"target": "",
+			},
+		},
+	}
+
+	var list []pictureQualitySetting
+	if err := d.doRequest(ctx, service, req, &list); err != nil {
+		if errors.Is(err, ErrDisplayOff) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	settings := make(map[string]PictureSetting, len(list))
+	for _, item := range list {
+		setting := PictureSetting{
+			Target:    item.Target,
+			Value:     item.CurrentValue,
+			Available: item.IsAvailable == nil || *item.IsAvailable,
+		}
+
+		for _, c := range item.Candidate {
+			switch {
+			case c.Min != nil && c.Max != nil:
+				setting.Range = &PictureRange{
+					Min:  *c.Min,
+					Max:  *c.Max,
+					Step: 1,
+				}
+
+				if c.Step != nil {
+					setting.Range.Step = *c.Step
+				}
+			case c.Value != "":
+				setting.Candidates = append(setting.Candidates, c.Value)
+			}
+		}
+
+		settings[item.Target] = setting
+	}
+
+	return settings, nil
+}
+
+// SetPictureSetting sets the picture quality setting target to value, and waits for the display to report the new value.
+func (d *Display) SetPictureSetting(ctx context.Context, target, value string) error {
+	service, err := d.pictureService(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := request{
+		Version: "1.0",
+		Method:  "setPictureQualitySettings",
+		Params: []map[string]interface{}{
+			{
+				"settings": []map[string]string{
+					{
+						"target": target,
+						"value":  value,
+					},
+				},
+			},
+		},
+	}
+
+	if err := d.doRequest(ctx, service, req); err != nil {
+		return err
+	}
+
+	// wait for the setting to change
+	return d.confirm(ctx, target, value, func(ctx context.Context) (interface{}, error) {
+		settings, err := d.PictureSettings(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return settings[target].Value, nil
+	})
+}
+
+// pictureService returns the service that picture quality settings are on. Most displays
+// have them on video, but some older firmware has them on videoScreen. If the display can't
+// report what it supports, video is assumed, like negotiate assumes the lowest version.
+func (d *Display) pictureService(ctx context.Context) (string, error) {
+	caps, err := d.capabilities(ctx)
+	if err != nil {
+		var bErr *Error
+		if errors.As(err, &bErr) {
+			return "video", nil
+		}
+
+		return "", fmt.Errorf("unable to get capabilities: %w", err)
+	}
+
+	for _, service := range []string{"video", "videoScreen"} {
+		if len(caps.Versions(service, "getPictureQualitySettings")) > 0 {
+			return service, nil
+		}
+	}
+
+	return "", fmt.Errorf("getPictureQualitySettings: %w", ErrUnsupported)
+}
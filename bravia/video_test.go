@@ -0,0 +1,89 @@
+package bravia
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/byuoitav/sony/bravia/braviatest"
+	"github.com/matryer/is"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestPictureSettings(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	is.NoErr(disp.SetPower(ctx, true))
+
+	settings, err := disp.PictureSettings(ctx)
+	is.NoErr(err)
+
+	for _, setting := range settings {
+		t.Logf("%+v", setting)
+	}
+
+	mode, ok := settings["pictureMode"]
+	is.True(ok)
+	is.True(len(mode.Candidates) > 0)
+
+	brightness, ok := settings["brightness"]
+	is.True(ok)
+	is.True(brightness.Range != nil)
+
+	is.NoErr(disp.SetPictureSetting(ctx, "pictureMode", mode.Candidates[0]))
+	is.NoErr(disp.SetPictureSetting(ctx, "brightness", "10"))
+
+	settings, err = disp.PictureSettings(ctx)
+	is.NoErr(err)
+	is.Equal(settings["pictureMode"].Value, mode.Candidates[0])
+	is.Equal(settings["brightness"].Value, "10")
+
+	// restore the original settings
+	is.NoErr(disp.SetPictureSetting(ctx, "pictureMode", mode.Value))
+	is.NoErr(disp.SetPictureSetting(ctx, "brightness", brightness.Value))
+
+	is.NoErr(disp.SetPower(ctx, false))
+}
+
+func TestOffPictureSettings(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	is.NoErr(disp.SetPower(ctx, false))
+
+	settings, err := disp.PictureSettings(ctx)
+	is.NoErr(err)
+	is.True(len(settings) == 0)
+}
+
+func TestPictureSettingsUnknownCapabilities(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s := braviatest.NewServer("")
+	defer s.Close()
+
+	// old firmware without getSupportedApiInfo
+	s.SetError("getSupportedApiInfo", braviatest.CodeNoSuchMethod)
+	s.SetState(func(s *braviatest.State) {
+		s.Power = true
+	})
+
+	d := &Display{
+		Address: s.Address(),
+		Log:     zaptest.NewLogger(t),
+	}
+
+	settings, err := d.PictureSettings(ctx)
+	is.NoErr(err)
+	is.True(len(settings) > 0)
+}
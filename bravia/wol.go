@@ -0,0 +1,234 @@
+package bravia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const _defaultWakeOnLANAddress = "255.255.255.255:9"
+
+// how often to resend the magic packet while waiting for the display to wake up.
+// It also limits each retry, so that a display dropping connections can't hold up the next packet.
+var _wakeResendInterval = 5 * time.Second
+
+type networkInterface struct {
+	Netif    string `json:"netif"`
+	HWAddr   string `json:"hwAddr"`
+	IPAddrV4 string `json:"ipAddrV4,omitempty"`
+}
+
+// WakeOnLAN returns true if the display will turn on when it receives a Wake-on-LAN packet.
+func (d *Display) WakeOnLAN(ctx context.Context) (bool, error) {
+	req := request{
+		Version: "1.0",
+		Method:  "getWolMode",
+		Params:  []map[string]interface{}{},
+	}
+
+	var mode struct {
+		Enabled bool `json:"enabled"`
+	}
+
+	if err := d.doRequest(ctx, "system", req, &mode); err != nil {
+		return false, err
+	}
+
+	d.wakeMu.Lock()
+	d.wolEnabled = &mode.Enabled
+	d.wakeMu.Unlock()
+
+	return mode.Enabled, nil
+}
+
+// SetWakeOnLAN enables or disables turning the display on with Wake-on-LAN packets.
+func (d *Display) SetWakeOnLAN(ctx context.Context, enabled bool) error {
+	req := request{
+		Version: "1.0",
+		Method:  "setWolMode",
+		Params: []map[string]interface{}{
+			{
+				"enabled": enabled,
+			},
+		},
+	}
+
+	if err := d.doRequest(ctx, "system", req); err != nil {
+		return err
+	}
+
+	d.wakeMu.Lock()
+	d.wolEnabled = &enabled
+	d.wakeMu.Unlock()
+
+	return nil
+}
+
+// learnWake remembers the display's mac address and Wake-on-LAN mode, so that it can be woken
+// up later when it isn't reachable. It only talks to the display the first time it is called,
+// even if it was unable to learn them.
+func (d *Display) learnWake(ctx context.Context) {
+	d.wakeMu.Lock()
+	learned := d.wakeTried || ((d.MACAddress != "" || d.wakeMAC != "") && d.wolEnabled != nil)
+	d.wakeTried = true
+	d.wakeMu.Unlock()
+
+	if learned {
+		return
+	}
+
+	if d.MACAddress == "" {
+		mac, err := d.getMACAddress(ctx)
+		if err != nil {
+			d.Log.Debug("Unable to learn mac address", zap.Error(err))
+			return
+		}
+
+		d.wakeMu.Lock()
+		d.wakeMAC = mac
+		d.wakeMu.Unlock()
+	}
+
+	if _, err := d.WakeOnLAN(ctx); err != nil {
+		d.Log.Debug("Unable to learn Wake-on-LAN mode", zap.Error(err))
+	}
+}
+
+// getMACAddress returns the mac address of the interface the display is connected with, falling back
+// to the mac address in the system information if the display can't report its network settings.
+func (d *Display) getMACAddress(ctx context.Context) (string, error) {
+	req := request{
+		Version: "1.0",
+		Method:  "getNetworkSettings",
+		Params: []map[string]interface{}{
+			{
+				"netif": "",
+			},
+		},
+	}
+
+	var ifaces []networkInterface
+	err := d.doRequest(ctx, "system", req, &ifaces)
+	switch {
+	case errors.Is(err, ErrUnsupported):
+		info, err := d.Info(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		return info.(Info).MACAddress, nil
+	case err != nil:
+		return "", err
+	}
+
+	mac := ""
+	for _, iface := range ifaces {
+		switch {
+		case iface.HWAddr == "":
+		case iface.IPAddrV4 != "":
+			return iface.HWAddr, nil
+		case mac == "":
+			mac = iface.HWAddr
+		}
+	}
+
+	if mac == "" {
+		return "", fmt.Errorf("no network interfaces with a mac address")
+	}
+
+	return mac, nil
+}
+
+// wake sends Wake-on-LAN packets to the display and retries req until the display accepts it.
+// Like confirm, it gives up after the confirmation policy's MaxWait.
+func (d *Display) wake(ctx context.Context, req request) error {
+	d.wakeMu.Lock()
+	mac, enabled := d.wakeMAC, d.wolEnabled
+	d.wakeMu.Unlock()
+
+	if d.MACAddress != "" {
+		mac = d.MACAddress
+	}
+
+	switch {
+	case mac == "":
+		return fmt.Errorf("unable to wake display: mac address unknown")
+	case enabled != nil && !*enabled:
+		return fmt.Errorf("unable to wake display: Wake-on-LAN is disabled")
+	}
+
+	hwAddr, err := net.ParseMAC(mac)
+	if err != nil {
+		return fmt.Errorf("unable to wake display: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.Confirm.maxWait())
+	defer cancel()
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	var lastSent time.Time
+	for {
+		if time.Since(lastSent) >= _wakeResendInterval {
+			d.Log.Info("Sending Wake-on-LAN packet", zap.String("mac", hwAddr.String()))
+
+			if err := d.sendMagicPacket(hwAddr); err != nil {
+				return fmt.Errorf("unable to wake display: %w", err)
+			}
+
+			lastSent = time.Now()
+		}
+
+		select {
+		case <-ticker.C:
+			retryCtx, cancel := context.WithTimeout(ctx, _wakeResendInterval)
+			err := d.doRequest(retryCtx, "system", req)
+			cancel()
+
+			var bErr *Error
+			switch {
+			case err == nil:
+				return nil
+			case errors.As(err, &bErr):
+				return err
+			}
+		case <-ctx.Done():
+			return &ErrNotConfirmed{
+				Setting: "power",
+				Want:    true,
+				Err:     fmt.Errorf("display didn't wake up: %w", ctx.Err()),
+			}
+		}
+	}
+}
+
+func (d *Display) sendMagicPacket(mac net.HardwareAddr) error {
+	addr := d.WakeOnLANAddress
+	if addr == "" {
+		addr = _defaultWakeOnLANAddress
+	}
+
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// 6 bytes of 0xff followed by the mac address 16 times
+	packet := make([]byte, 0, 6+16*len(mac))
+	for i := 0; i < 6; i++ {
+		packet = append(packet, 0xff)
+	}
+
+	for i := 0; i < 16; i++ {
+		packet = append(packet, mac...)
+	}
+
+	_, err = conn.Write(packet)
+	return err
+}
@@ -0,0 +1,267 @@
+package bravia
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/byuoitav/sony/bravia/braviatest"
+	"github.com/matryer/is"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestWakeOnLAN(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	srv := braviatest.NewServer("")
+	defer srv.Close()
+
+	d := &Display{
+		Address:          srv.Address(),
+		Log:              zaptest.NewLogger(t),
+		RequestDelay:     10 * time.Millisecond,
+		WakeOnLANAddress: srv.WakeOnLANAddress(),
+	}
+
+	// learns the mac address and wol mode
+	is.NoErr(d.SetPower(ctx, false))
+
+	enabled, err := d.WakeOnLAN(ctx)
+	is.NoErr(err)
+	is.True(enabled)
+
+	srv.SetState(func(s *braviatest.State) {
+		s.DeepStandby = true
+	})
+
+	is.NoErr(d.SetPower(ctx, true))
+	is.True(!srv.State().DeepStandby)
+	is.True(srv.State().Power)
+}
+
+func TestWakeOnLANDisabled(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	srv := braviatest.NewServer("")
+	defer srv.Close()
+
+	d := &Display{
+		Address:          srv.Address(),
+		Log:              zaptest.NewLogger(t),
+		RequestDelay:     10 * time.Millisecond,
+		WakeOnLANAddress: srv.WakeOnLANAddress(),
+	}
+
+	is.NoErr(d.SetWakeOnLAN(ctx, false))
+	is.True(!srv.State().WakeOnLAN)
+	is.NoErr(d.SetPower(ctx, false))
+
+	srv.SetState(func(s *braviatest.State) {
+		s.DeepStandby = true
+	})
+
+	is.True(d.SetPower(ctx, true) != nil)
+	is.True(srv.State().DeepStandby)
+}
+
+func TestWakeOnLANConfiguredMAC(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	srv := braviatest.NewServer("")
+	defer srv.Close()
+
+	srv.SetState(func(s *braviatest.State) {
+		s.DeepStandby = true
+	})
+
+	// never talked to the display, so the mac address must be configured
+	d := &Display{
+		Address:          srv.Address(),
+		Log:              zaptest.NewLogger(t),
+		RequestDelay:     10 * time.Millisecond,
+		MACAddress:       srv.State().Info.MACAddress,
+		WakeOnLANAddress: srv.WakeOnLANAddress(),
+	}
+
+	is.NoErr(d.SetPower(ctx, true))
+	is.True(srv.State().Power)
+}
+
+func TestWakeGivesUp(t *testing.T) {
+	is := is.New(t)
+
+	srv := braviatest.NewServer("")
+	defer srv.Close()
+
+	srv.SetState(func(s *braviatest.State) {
+		s.WakeOnLAN = false
+		s.DeepStandby = true
+	})
+
+	// the display ignores the packets, but that can't be known because it was never reachable
+	d := &Display{
+		Address:          srv.Address(),
+		Log:              zaptest.NewLogger(t),
+		RequestDelay:     10 * time.Millisecond,
+		MACAddress:       srv.State().Info.MACAddress,
+		WakeOnLANAddress: srv.WakeOnLANAddress(),
+		Confirm: ConfirmPolicy{
+			MaxWait: time.Second,
+		},
+	}
+
+	start := time.Now()
+	err := d.SetPower(context.Background(), true)
+	is.True(time.Since(start) < 5*time.Second)
+
+	var notConfirmed *ErrNotConfirmed
+	is.True(errors.As(err, &notConfirmed))
+	is.True(errors.Is(err, context.DeadlineExceeded))
+	is.True(srv.State().DeepStandby)
+}
+
+// countingTransport counts the requests made for each method.
+type countingTransport struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		var r request
+		if err := json.Unmarshal(body, &r); err == nil {
+			c.mu.Lock()
+			c.counts[r.Method]++
+			c.mu.Unlock()
+		}
+
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func (c *countingTransport) count(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.counts[method]
+}
+
+func TestLearnWakeOnce(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	srv := braviatest.NewServer("")
+	defer srv.Close()
+
+	// the wol mode can't be learned
+	srv.SetError("getWolMode", braviatest.CodeIllegalState)
+
+	transport := &countingTransport{counts: make(map[string]int)}
+	d := &Display{
+		Address:      srv.Address(),
+		Log:          zaptest.NewLogger(t),
+		RequestDelay: 10 * time.Millisecond,
+		MACAddress:   srv.State().Info.MACAddress,
+		HTTPClient:   &http.Client{Transport: transport},
+	}
+
+	is.NoErr(d.SetPower(ctx, true))
+	is.NoErr(d.SetPower(ctx, false))
+	is.NoErr(d.SetPower(ctx, true))
+
+	is.Equal(transport.count("getWolMode"), 1)
+	is.Equal(transport.count("getNetworkSettings"), 0)
+}
+
+func TestWakeUnresponsive(t *testing.T) {
+	is := is.New(t)
+
+	resend := _wakeResendInterval
+	_wakeResendInterval = 200 * time.Millisecond
+	defer func() {
+		_wakeResendInterval = resend
+	}()
+
+	// a display that accepts connections but never answers
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	is.NoErr(err)
+	defer lis.Close()
+
+	go func() {
+		var conns []net.Conn
+		defer func() {
+			for _, conn := range conns {
+				conn.Close()
+			}
+		}()
+
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+
+			conns = append(conns, conn)
+		}
+	}()
+
+	// count the magic packets
+	wol, err := net.ListenPacket("udp", "127.0.0.1:0")
+	is.NoErr(err)
+	defer wol.Close()
+
+	var packets int32
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			if _, _, err := wol.ReadFrom(buf); err != nil {
+				return
+			}
+
+			atomic.AddInt32(&packets, 1)
+		}
+	}()
+
+	d := &Display{
+		Address:          lis.Addr().String(),
+		Log:              zaptest.NewLogger(t),
+		Timeout:          200 * time.Millisecond,
+		MACAddress:       "00:00:5e:00:53:01",
+		WakeOnLANAddress: wol.LocalAddr().String(),
+		Confirm: ConfirmPolicy{
+			MaxWait: 2 * time.Second,
+		},
+	}
+
+	var notConfirmed *ErrNotConfirmed
+	is.True(errors.As(d.SetPower(context.Background(), true), &notConfirmed))
+
+	// packets kept being sent while the retries hung
+	is.True(atomic.LoadInt32(&packets) >= 2)
+}
@@ -6,6 +6,7 @@ require (
 	github.com/byuoitav/common v0.0.0-20200521193927-1fdf4e0a4271 // indirect
 	github.com/byuoitav/pooled v0.0.0-20191022213741-dad43433a657
 	github.com/fatih/color v1.9.0 // indirect
+	github.com/gorilla/websocket v1.4.2
 	github.com/labstack/echo v3.3.10+incompatible // indirect
 	github.com/labstack/gommon v0.3.0 // indirect
 	github.com/matryer/is v1.4.0
@@ -0,0 +1,70 @@
+package ssip
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Volumes returns the volume of the display for each block. The display only has
+// a single volume, so every block has the same level.
+func (d *Display) Volumes(ctx context.Context, blocks []string) (map[string]int, error) {
+	resp, err := d.enquire(ctx, "VOLU")
+	if err != nil {
+		return nil, err
+	}
+
+	vol, err := strconv.Atoi(resp)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected response: %s", resp)
+	}
+
+	vols := make(map[string]int, len(blocks))
+	for _, block := range blocks {
+		vols[block] = vol
+	}
+
+	return vols, nil
+}
+
+// SetVolume sets the volume of the display. The display only has a single volume, so block is ignored.
+func (d *Display) SetVolume(ctx context.Context, block string, vol int) error {
+	if vol < 0 {
+		return fmt.Errorf("invalid volume %v", vol)
+	}
+
+	return d.control(ctx, "VOLU", numberParam(vol))
+}
+
+// Mutes returns whether the display is muted for each block. The display only has
+// a single mute, so every block has the same state.
+func (d *Display) Mutes(ctx context.Context, blocks []string) (map[string]bool, error) {
+	resp, err := d.enquire(ctx, "AMUT")
+	if err != nil {
+		return nil, err
+	}
+
+	mutes := make(map[string]bool, len(blocks))
+	for _, block := range blocks {
+		mutes[block] = resp == numberParam(1)
+	}
+
+	return mutes, nil
+}
+
+// SetMute sets mute on the display. The display only has a single mute, so block is ignored.
+func (d *Display) SetMute(ctx context.Context, block string, mute bool) error {
+	if err := d.control(ctx, "AMUT", boolParam(mute)); err != nil {
+		return err
+	}
+
+	// wait for display to mute
+	return d.confirm(ctx, "mute", mute, func(ctx context.Context) (interface{}, error) {
+		mutes, err := d.Mutes(ctx, []string{block})
+		if err != nil {
+			return nil, err
+		}
+
+		return mutes[block], nil
+	})
+}
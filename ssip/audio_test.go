@@ -0,0 +1,42 @@
+package ssip
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestVolume(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, vol := range []int{0, 35, 100} {
+		is.NoErr(disp.SetVolume(ctx, "speaker", vol))
+
+		vols, err := disp.Volumes(ctx, []string{"speaker"})
+		is.NoErr(err)
+		is.Equal(vols["speaker"], vol)
+	}
+}
+
+func TestMute(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, muted := range []bool{true, false} {
+		is.NoErr(disp.SetMute(ctx, "speaker", muted))
+
+		mutes, err := disp.Mutes(ctx, []string{"speaker"})
+		is.NoErr(err)
+		is.Equal(mutes["speaker"], muted)
+	}
+}
@@ -0,0 +1,52 @@
+package ssip
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestNotConfirmed(t *testing.T) {
+	is := is.New(t)
+
+	d := &Display{
+		Address: disp.Address,
+		Port:    disp.Port,
+		Log:     zaptest.NewLogger(t),
+		Confirm: ConfirmPolicy{
+			Interval: 100 * time.Millisecond,
+			MaxWait:  time.Second,
+		},
+	}
+	defer d.Close()
+
+	// the display accepts the command, but never mutes
+	fake.mu.Lock()
+	fake.ignored["AMUT"] = true
+	fake.mu.Unlock()
+
+	defer func() {
+		fake.mu.Lock()
+		delete(fake.ignored, "AMUT")
+		fake.mu.Unlock()
+	}()
+
+	// gives up even though the context has no deadline
+	start := time.Now()
+	err := d.SetMute(context.Background(), "", true)
+	is.True(time.Since(start) < 3*time.Second)
+
+	var notConfirmed *ErrNotConfirmed
+	is.True(errors.As(err, &notConfirmed))
+	is.Equal(notConfirmed.Setting, "mute")
+	is.Equal(notConfirmed.Last, false)
+	is.True(errors.Is(err, context.DeadlineExceeded))
+
+	// confirmation can be skipped
+	d.Confirm.Disabled = true
+	is.NoErr(d.SetMute(context.Background(), "", true))
+}
@@ -0,0 +1,294 @@
+package ssip
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultPort is the port displays serve SSIP on
+	DefaultPort = 20060
+
+	_defaultTimeout = 5 * time.Second
+)
+
+type Display struct {
+	Address string
+	Log     *zap.Logger
+
+	// Port defaults to DefaultPort if not set.
+	Port int
+
+	RequestDelay time.Duration
+
+	// Confirm controls how setters wait for the display to report the state they set.
+	Confirm ConfirmPolicy
+
+	// OnNotify, if set, is called with every notify frame the display sends,
+	// ie when the display is powered on with the remote. Frames are read as soon as
+	// they arrive while a connection is open, which it is from the first request on.
+	//
+	// OnNotify is called from its own goroutine, one frame at a time, so it may call the
+	// display's methods. It must not block: frames that arrive while too many are
+	// waiting for it are dropped.
+	OnNotify func(Frame)
+
+	once    sync.Once
+	limiter *rate.Limiter
+
+	mu sync.Mutex
+	c  *connection
+}
+
+// connection is an open connection to the display, read by its own goroutine.
+type connection struct {
+	conn net.Conn
+	buf  []byte
+
+	// answers receives the answer frames read from conn
+	answers chan Frame
+
+	// done is closed when conn can't be read anymore, and err is why
+	done chan struct{}
+	err  error
+}
+
+// how many frames are kept for send or OnNotify before frames are dropped
+const _frameQueueLen = 16
+
+func (d *Display) init() {
+	d.limiter = rate.NewLimiter(rate.Every(d.RequestDelay), 1)
+
+	if d.Log == nil {
+		d.Log = zap.NewNop()
+	}
+}
+
+// enquire asks the display for the value of function and returns the param it answered with.
+func (d *Display) enquire(ctx context.Context, function string) (string, error) {
+	return d.send(ctx, Frame{Type: TypeEnquiry, Function: function, Param: _paramQuery})
+}
+
+// enquireWith asks the display for the value of function, passing param (ie, the network interface).
+func (d *Display) enquireWith(ctx context.Context, function, param string) (string, error) {
+	return d.send(ctx, Frame{Type: TypeEnquiry, Function: function, Param: param})
+}
+
+// control sets function to param.
+func (d *Display) control(ctx context.Context, function, param string) error {
+	resp, err := d.send(ctx, Frame{Type: TypeControl, Function: function, Param: param})
+	if err != nil {
+		return err
+	}
+
+	if resp != _paramSuccess {
+		return fmt.Errorf("unexpected response: %s", resp)
+	}
+
+	return nil
+}
+
+func (d *Display) send(ctx context.Context, req Frame) (string, error) {
+	d.once.Do(d.init)
+
+	b, err := req.bytes()
+	if err != nil {
+		return "", fmt.Errorf("unable to build frame: %w", err)
+	}
+
+	if err := d.limiter.Wait(ctx); err != nil {
+		return "", fmt.Errorf("unable to wait for ratelimit: %w", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(_defaultTimeout)
+	}
+
+	// retry once on a fresh connection if the old one was closed by the display
+	var resp Frame
+	for i := 0; i < 2; i++ {
+		var c *connection
+		if c, err = d.connect(ctx); err != nil {
+			return "", fmt.Errorf("unable to connect: %w", err)
+		}
+
+		d.Log.Debug("Sending frame", zap.Stringer("frame", req))
+
+		resp, err = c.roundTrip(ctx, b, req.Function, deadline)
+		if err == nil {
+			break
+		}
+
+		d.close()
+
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	switch resp.Param {
+	case _paramError:
+		return "", fmt.Errorf("%s: %w", req.Function, ErrFailed)
+	case _paramMissing:
+		return "", fmt.Errorf("%s: %w", req.Function, ErrNotFound)
+	}
+
+	return resp.Param, nil
+}
+
+// roundTrip writes b and waits for the answer to function to be read. It must be called with d.mu held.
+func (c *connection) roundTrip(ctx context.Context, b []byte, function string, deadline time.Time) (Frame, error) {
+	// drop answers nobody was waiting for
+	for len(c.answers) > 0 {
+		<-c.answers
+	}
+
+	c.conn.SetWriteDeadline(deadline)
+	if _, err := c.conn.Write(b); err != nil {
+		return Frame{}, fmt.Errorf("unable to write frame: %w", err)
+	}
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+
+	for {
+		select {
+		case frame := <-c.answers:
+			if frame.Function == function {
+				return frame, nil
+			}
+		case <-c.done:
+			return Frame{}, c.err
+		case <-timer.C:
+			return Frame{}, fmt.Errorf("timed out waiting for answer to %s", function)
+		case <-ctx.Done():
+			return Frame{}, ctx.Err()
+		}
+	}
+}
+
+// read reads frames from c until it is closed, passing answers to send and notify frames to OnNotify.
+func (d *Display) read(c *connection) {
+	notify := make(chan Frame, _frameQueueLen)
+	defer close(notify)
+
+	go func() {
+		for frame := range notify {
+			if d.OnNotify != nil {
+				d.OnNotify(frame)
+			}
+		}
+	}()
+
+	defer close(c.done)
+
+	for {
+		frame, err := c.readFrame()
+		if err != nil {
+			c.err = err
+			return
+		}
+
+		d.Log.Debug("Received frame", zap.Stringer("frame", frame))
+
+		queue := c.answers
+		if frame.Type == TypeNotify {
+			queue = notify
+		}
+
+		select {
+		case queue <- frame:
+		default:
+			d.Log.Warn("Dropping frame, too many are waiting to be handled", zap.Stringer("frame", frame))
+		}
+	}
+}
+
+func (c *connection) readFrame() (Frame, error) {
+	for {
+		if len(c.buf) >= _frameLen {
+			b := c.buf[:_frameLen]
+			c.buf = c.buf[_frameLen:]
+
+			return parseFrame(b)
+		}
+
+		tmp := make([]byte, _frameLen)
+		n, err := c.conn.Read(tmp)
+		if n > 0 {
+			c.buf = append(c.buf, tmp[:n]...)
+			continue
+		}
+
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return Frame{}, fmt.Errorf("unable to read frame: %w", err)
+	}
+}
+
+// connect returns the open connection, opening a new one if the display closed it. It must be called with d.mu held.
+func (d *Display) connect(ctx context.Context) (*connection, error) {
+	if d.c != nil {
+		select {
+		case <-d.c.done:
+			d.close()
+		default:
+			return d.c, nil
+		}
+	}
+
+	port := d.Port
+	if port == 0 {
+		port = DefaultPort
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(d.Address, strconv.Itoa(port)))
+	if err != nil {
+		return nil, err
+	}
+
+	d.c = &connection{
+		conn:    conn,
+		answers: make(chan Frame, _frameQueueLen),
+		done:    make(chan struct{}),
+	}
+
+	go d.read(d.c)
+	return d.c, nil
+}
+
+// close must be called with d.mu held.
+func (d *Display) close() {
+	if d.c != nil {
+		d.c.conn.Close()
+		d.c = nil
+	}
+}
+
+// Close closes the connection to the display, if one is open.
+func (d *Display) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.close()
+	return nil
+}
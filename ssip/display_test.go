@@ -0,0 +1,144 @@
+package ssip
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"testing"
+)
+
+var (
+	disp *Display
+	fake *fakeDisplay
+)
+
+// fakeDisplay answers SSIP frames from a map of function to param. If notify
+// is set, a notify frame is sent before every answer.
+type fakeDisplay struct {
+	l net.Listener
+
+	mu     sync.Mutex
+	params map[string]string
+	notify *Frame
+	conns  map[net.Conn]bool
+
+	// ignored functions answer controls with success without changing
+	ignored map[string]bool
+}
+
+// push sends frame to every open connection, without being asked.
+func (f *fakeDisplay) push(frame Frame) {
+	b, _ := frame.bytes()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for conn := range f.conns {
+		conn.Write(b)
+	}
+}
+
+func (f *fakeDisplay) serve() {
+	for {
+		conn, err := f.l.Accept()
+		if err != nil {
+			return
+		}
+
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeDisplay) handle(conn net.Conn) {
+	f.mu.Lock()
+	f.conns[conn] = true
+	f.mu.Unlock()
+
+	defer func() {
+		f.mu.Lock()
+		delete(f.conns, conn)
+		f.mu.Unlock()
+
+		conn.Close()
+	}()
+
+	r := bufio.NewReader(conn)
+
+	for {
+		b := make([]byte, _frameLen)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return
+		}
+
+		req, err := parseFrame(b)
+		if err != nil {
+			return
+		}
+
+		f.mu.Lock()
+		resp := Frame{Type: TypeAnswer, Function: req.Function}
+		param, ok := f.params[req.Function]
+
+		switch {
+		case !ok:
+			resp.Param = _paramMissing
+		case req.Type == TypeEnquiry:
+			resp.Param = param
+		case req.Type == TypeControl:
+			if !f.ignored[req.Function] {
+				f.params[req.Function] = req.Param
+			}
+
+			resp.Param = _paramSuccess
+		}
+
+		notify := f.notify
+		f.mu.Unlock()
+
+		f.mu.Lock()
+		if notify != nil {
+			b, _ := notify.bytes()
+			conn.Write(b)
+		}
+
+		b, _ = resp.bytes()
+		conn.Write(b)
+		f.mu.Unlock()
+	}
+}
+
+func TestMain(m *testing.M) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+
+	fake = &fakeDisplay{
+		l:       l,
+		conns:   make(map[net.Conn]bool),
+		ignored: make(map[string]bool),
+		params: map[string]string{
+			"POWR": numberParam(0),
+			"INPT": "0000000100000001",
+			"VOLU": numberParam(20),
+			"AMUT": numberParam(0),
+			"PMUT": numberParam(0),
+			"IPAD": padParam("192.0.2.20"),
+			"MADR": padParam("00005e005303"),
+		},
+	}
+
+	go fake.serve()
+
+	disp = &Display{
+		Address: "127.0.0.1",
+		Port:    l.Addr().(*net.TCPAddr).Port,
+	}
+
+	code := m.Run()
+	disp.Close()
+	l.Close()
+	os.Exit(code)
+}
@@ -0,0 +1,4 @@
+/*
+Package ssip provides a struct for controling Sony BRAVIA displays using Simple IP Control (SSIP) over TCP port 20060. It offers the same methods as bravia.Display, for displays that have the REST API disabled. The protocol spec can be found at https://pro-bravia.sony.net/develop/integrate/ssip/overview/index.html.
+*/
+package ssip
@@ -0,0 +1,11 @@
+package ssip
+
+import "errors"
+
+var (
+	// ErrFailed is returned when the display answers a command with an error
+	ErrFailed = errors.New("display returned an error")
+
+	// ErrNotFound is returned when the display answers that the target of a command does not exist
+	ErrNotFound = errors.New("target not found")
+)
@@ -0,0 +1,85 @@
+package ssip
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	_frameLen = 24
+	_paramLen = 16
+	_header   = "*S"
+	_footer   = '\n'
+)
+
+// Frame types
+const (
+	TypeControl = 'C'
+	TypeEnquiry = 'E'
+	TypeAnswer  = 'A'
+	TypeNotify  = 'N'
+)
+
+var (
+	_paramQuery   = strings.Repeat("#", _paramLen)
+	_paramSuccess = strings.Repeat("0", _paramLen)
+	_paramError   = strings.Repeat("F", _paramLen)
+	_paramMissing = strings.Repeat("N", _paramLen)
+)
+
+// Frame is a single fixed-length SSIP message.
+type Frame struct {
+	Type     byte
+	Function string
+	Param    string
+}
+
+func (f Frame) bytes() ([]byte, error) {
+	if len(f.Function) != 4 {
+		return nil, fmt.Errorf("function %q must be 4 characters", f.Function)
+	}
+
+	if len(f.Param) != _paramLen {
+		return nil, fmt.Errorf("param %q must be %v characters", f.Param, _paramLen)
+	}
+
+	b := make([]byte, 0, _frameLen)
+	b = append(b, _header...)
+	b = append(b, f.Type)
+	b = append(b, f.Function...)
+	b = append(b, f.Param...)
+	b = append(b, _footer)
+
+	return b, nil
+}
+
+func parseFrame(b []byte) (Frame, error) {
+	if len(b) != _frameLen || string(b[:2]) != _header || b[_frameLen-1] != _footer {
+		return Frame{}, fmt.Errorf("invalid frame %q", b)
+	}
+
+	return Frame{
+		Type:     b[2],
+		Function: string(b[3:7]),
+		Param:    string(b[7 : 7+_paramLen]),
+	}, nil
+}
+
+// String returns the frame as it is sent on the wire, without the footer.
+func (f Frame) String() string {
+	return fmt.Sprintf("%s%c%s%s", _header, f.Type, f.Function, f.Param)
+}
+
+// numberParam formats n as a zero padded param.
+func numberParam(n int) string {
+	return fmt.Sprintf("%0*d", _paramLen, n)
+}
+
+// padParam pads str on the right with '#' to the length of a param.
+func padParam(str string) string {
+	if len(str) >= _paramLen {
+		return str[:_paramLen]
+	}
+
+	return str + strings.Repeat("#", _paramLen-len(str))
+}
@@ -0,0 +1,42 @@
+package ssip
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestFrame(t *testing.T) {
+	is := is.New(t)
+
+	f := Frame{Type: TypeControl, Function: "POWR", Param: numberParam(1)}
+
+	b, err := f.bytes()
+	is.NoErr(err)
+	is.Equal(string(b), "*SCPOWR0000000000000001\n")
+
+	parsed, err := parseFrame(b)
+	is.NoErr(err)
+	is.Equal(parsed, f)
+
+	_, err = parseFrame([]byte("*SCPOWR00000001\n"))
+	is.True(err != nil)
+
+	_, err = Frame{Type: TypeControl, Function: "POW", Param: numberParam(1)}.bytes()
+	is.True(err != nil)
+}
+
+func TestInputParam(t *testing.T) {
+	is := is.New(t)
+
+	param, err := inputParam("hdmi?port=3")
+	is.NoErr(err)
+	is.Equal(param, "0000000100000003")
+
+	input, err := parseInput(param)
+	is.NoErr(err)
+	is.Equal(input, "hdmi?port=3")
+
+	_, err = inputParam("hdmi3")
+	is.True(err != nil)
+}
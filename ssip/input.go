@@ -0,0 +1,101 @@
+package ssip
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// inputTypes maps the SSIP input types to the names used in BRAVIA REST API uris,
+// so inputs are named the same as they are by bravia.Display.
+var inputTypes = map[int]string{
+	1: "hdmi",
+	2: "scart",
+	3: "composite",
+	4: "component",
+	5: "widi",
+	6: "pc",
+}
+
+// AudioVideoInputs returns the current input of the display, in the same format as bravia.Display (ie, "hdmi?port=1").
+func (d *Display) AudioVideoInputs(ctx context.Context) (map[string]string, error) {
+	resp, err := d.enquire(ctx, "INPT")
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := parseInput(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"": input,
+	}, nil
+}
+
+// SetAudioVideoInput sets the input of the display to the given input, in the same format as bravia.Display (ie, "hdmi?port=1").
+func (d *Display) SetAudioVideoInput(ctx context.Context, _, input string) error {
+	param, err := inputParam(input)
+	if err != nil {
+		return err
+	}
+
+	if err := d.control(ctx, "INPT", param); err != nil {
+		return err
+	}
+
+	// wait for input to change
+	return d.confirm(ctx, "input", input, func(ctx context.Context) (interface{}, error) {
+		inputs, err := d.AudioVideoInputs(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return inputs[""], nil
+	})
+}
+
+func parseInput(param string) (string, error) {
+	if len(param) != _paramLen {
+		return "", fmt.Errorf("unexpected response: %s", param)
+	}
+
+	typ, err := strconv.Atoi(param[:8])
+	if err != nil {
+		return "", fmt.Errorf("unexpected response: %s", param)
+	}
+
+	port, err := strconv.Atoi(param[8:])
+	if err != nil {
+		return "", fmt.Errorf("unexpected response: %s", param)
+	}
+
+	name, ok := inputTypes[typ]
+	if !ok {
+		return "", fmt.Errorf("unknown input type %v", typ)
+	}
+
+	return fmt.Sprintf("%s?port=%d", name, port), nil
+}
+
+func inputParam(input string) (string, error) {
+	parts := strings.SplitN(input, "?port=", 2)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("invalid input %q", input)
+	}
+
+	port, err := strconv.Atoi(parts[1])
+	if err != nil || port < 0 {
+		return "", fmt.Errorf("invalid input %q", input)
+	}
+
+	for typ, name := range inputTypes {
+		if name == parts[0] {
+			return fmt.Sprintf("%08d%08d", typ, port), nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid input %q", input)
+}
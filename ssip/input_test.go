@@ -0,0 +1,37 @@
+package ssip
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestAudioVideoInput(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	input := "hdmi?port=2"
+	is.NoErr(disp.SetAudioVideoInput(ctx, "", input))
+
+	inputs, err := disp.AudioVideoInputs(ctx)
+	is.NoErr(err)
+	is.Equal(inputs[""], input)
+}
+
+func TestNotFound(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := disp.enquire(ctx, "XXXX")
+	is.True(errors.Is(err, ErrNotFound))
+}
@@ -0,0 +1,105 @@
+package ssip
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (d *Display) Power(ctx context.Context) (bool, error) {
+	resp, err := d.enquire(ctx, "POWR")
+	if err != nil {
+		return false, err
+	}
+
+	return resp == numberParam(1), nil
+}
+
+func (d *Display) SetPower(ctx context.Context, power bool) error {
+	if err := d.control(ctx, "POWR", boolParam(power)); err != nil {
+		return err
+	}
+
+	// wait for display to turn on
+	return d.confirm(ctx, "power", power, func(ctx context.Context) (interface{}, error) {
+		return d.Power(ctx)
+	})
+}
+
+// Blank returns true if the display's picture is muted.
+func (d *Display) Blank(ctx context.Context) (bool, error) {
+	resp, err := d.enquire(ctx, "PMUT")
+	if err != nil {
+		return false, err
+	}
+
+	return resp == numberParam(1), nil
+}
+
+// SetBlank mutes or unmutes the display's picture.
+func (d *Display) SetBlank(ctx context.Context, blanked bool) error {
+	if err := d.control(ctx, "PMUT", boolParam(blanked)); err != nil {
+		return err
+	}
+
+	// wait for display to blank
+	return d.confirm(ctx, "blank", blanked, func(ctx context.Context) (interface{}, error) {
+		return d.Blank(ctx)
+	})
+}
+
+type Info struct {
+	IPAddress  string `json:"ipAddress"`
+	MACAddress string `json:"macAddr"`
+}
+
+// Info returns the network information of the display's wired interface.
+func (d *Display) Info(ctx context.Context) (interface{}, error) {
+	var info Info
+
+	resp, err := d.enquireWith(ctx, "IPAD", padParam("eth0"))
+	if err != nil {
+		return info, err
+	}
+
+	info.IPAddress = strings.TrimRight(resp, "#")
+
+	resp, err = d.enquireWith(ctx, "MADR", padParam("eth0"))
+	if err != nil {
+		return info, err
+	}
+
+	info.MACAddress = formatMAC(strings.TrimRight(resp, "#"))
+
+	return info, nil
+}
+
+func (d *Display) Healthy(ctx context.Context) error {
+	if _, err := d.Power(ctx); err != nil {
+		return fmt.Errorf("failed health check: %w", err)
+	}
+
+	return nil
+}
+
+func boolParam(b bool) string {
+	if b {
+		return numberParam(1)
+	}
+
+	return numberParam(0)
+}
+
+// formatMAC turns "0123456789ab" into "01:23:45:67:89:ab".
+func formatMAC(str string) string {
+	if len(str) != 12 {
+		return str
+	}
+
+	var parts []string
+	for i := 0; i < len(str); i += 2 {
+		parts = append(parts, str[i:i+2])
+	}
+
+	return strings.ToLower(strings.Join(parts, ":"))
+}
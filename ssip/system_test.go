@@ -0,0 +1,136 @@
+package ssip
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestPower turns the display on and then off, verifying that
+// it works after each step.
+func TestPower(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	is.NoErr(disp.SetPower(ctx, true))
+
+	pow, err := disp.Power(ctx)
+	is.NoErr(err)
+	is.True(pow)
+
+	is.NoErr(disp.SetPower(ctx, false))
+
+	pow, err = disp.Power(ctx)
+	is.NoErr(err)
+	is.True(!pow)
+}
+
+func TestBlank(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	is.NoErr(disp.SetBlank(ctx, true))
+
+	blanked, err := disp.Blank(ctx)
+	is.NoErr(err)
+	is.True(blanked)
+
+	is.NoErr(disp.SetBlank(ctx, false))
+}
+
+func TestInfo(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	info, err := disp.Info(ctx)
+	is.NoErr(err)
+	is.Equal(info.(Info).IPAddress, "192.0.2.20")
+	is.Equal(info.(Info).MACAddress, "00:00:5e:00:53:03")
+}
+
+func TestNotify(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	notify := Frame{Type: TypeNotify, Function: "POWR", Param: numberParam(1)}
+
+	fake.mu.Lock()
+	fake.notify = &notify
+	fake.mu.Unlock()
+
+	defer func() {
+		fake.mu.Lock()
+		fake.notify = nil
+		fake.mu.Unlock()
+
+		disp.OnNotify = nil
+	}()
+
+	got := make(chan Frame, 1)
+	disp.OnNotify = func(f Frame) {
+		got <- f
+	}
+
+	// the notify frame shouldn't be mistaken for the answer
+	_, err := disp.Volumes(ctx, []string{"speaker"})
+	is.NoErr(err)
+
+	select {
+	case f := <-got:
+		is.Equal(f, notify)
+	case <-ctx.Done():
+		t.Fatal("notify frame wasn't received")
+	}
+}
+
+func TestNotifyIdle(t *testing.T) {
+	is := is.New(t)
+	disp.Log = zaptest.NewLogger(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	defer func() {
+		disp.OnNotify = nil
+	}()
+
+	// OnNotify can use the display
+	got := make(chan bool, 1)
+	disp.OnNotify = func(f Frame) {
+		pow, err := disp.Power(ctx)
+		is.NoErr(err)
+		got <- pow
+	}
+
+	// open the connection
+	is.NoErr(disp.SetPower(ctx, true))
+
+	// nothing is being sent when the display turns itself off
+	fake.mu.Lock()
+	fake.params["POWR"] = numberParam(0)
+	fake.mu.Unlock()
+
+	fake.push(Frame{Type: TypeNotify, Function: "POWR", Param: numberParam(0)})
+
+	select {
+	case pow := <-got:
+		is.True(!pow)
+	case <-ctx.Done():
+		t.Fatal("notify frame wasn't received")
+	}
+}